@@ -0,0 +1,72 @@
+//go:build gpgme_1_19
+// +build gpgme_1_19
+
+package gpgme
+
+// #include <gpgme.h>
+import "C"
+
+import "strings"
+
+const (
+	// EncryptArchive encrypts a gpgtar archive of the files and directories
+	// named by the plaintext, see EncryptDir.
+	EncryptArchive EncryptFlag = C.GPGME_ENCRYPT_ARCHIVE
+	// SigModeArchive signs a gpgtar archive of the files and directories
+	// named by the plaintext, see SignDir.
+	SigModeArchive SigMode = C.GPGME_SIG_MODE_ARCHIVE
+	// DecryptArchive extracts a decrypted gpgtar archive, see DecryptToDir.
+	DecryptArchive DecryptFlag = C.GPGME_DECRYPT_ARCHIVE
+)
+
+// archiveList returns the plaintext for an archive operation: the paths,
+// relative to baseDir, separated by newlines.
+func archiveList(baseDir string, paths []string) (*Data, error) {
+	list, err := NewDataBytes([]byte(strings.Join(paths, "\n")))
+	if err != nil {
+		return nil, err
+	}
+	if err := list.SetName(baseDir); err != nil {
+		list.Close()
+		return nil, err
+	}
+	return list, nil
+}
+
+// EncryptDir writes an encrypted gpgtar archive of paths, relative to baseDir,
+// to ciphertext. An empty baseDir is the current directory.
+func (c *Context) EncryptDir(recipients []*Key, flags EncryptFlag, baseDir string, paths []string, ciphertext *Data) error {
+	list, err := archiveList(baseDir, paths)
+	if err != nil {
+		return err
+	}
+	defer list.Close()
+	return c.Encrypt(recipients, flags|EncryptArchive, list, ciphertext)
+}
+
+// SignDir writes a signed gpgtar archive of paths, relative to baseDir, to
+// sig.
+func (c *Context) SignDir(signers []*Key, baseDir string, paths []string, sig *Data) error {
+	list, err := archiveList(baseDir, paths)
+	if err != nil {
+		return err
+	}
+	defer list.Close()
+	return c.Sign(signers, list, sig, SigModeArchive)
+}
+
+// DecryptToDir decrypts a gpgtar archive from ciphertext and extracts it into
+// dir, which is created if needed. gpgtar writes the files itself, so the
+// limit of SetMaxPlaintextBytes does not apply.
+func (c *Context) DecryptToDir(ciphertext *Data, dir string) error {
+	out, err := NewData()
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	if err := out.SetName(dir); err != nil {
+		return err
+	}
+	_, _, err = c.decryptExt(DecryptArchive, ciphertext, out, false)
+	return err
+}
@@ -0,0 +1,46 @@
+//go:build gpgme_1_19
+// +build gpgme_1_19
+
+package gpgme
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestContext_EncryptDir(t *testing.T) {
+	ctx := tempHomeContext(t)
+	key := newTestKey(t, ctx, "Archive <archive@example.com>", "rsa2048", CreateEncrypt|CreateNoPassword)
+
+	srcDir, err := ioutil.TempDir("", "gpgme-archive-src")
+	checkError(t, err)
+	defer os.RemoveAll(srcDir)
+	checkError(t, os.MkdirAll(filepath.Join(srcDir, "tree", "sub"), 0o755))
+	checkError(t, ioutil.WriteFile(filepath.Join(srcDir, "tree", "sub", "file.txt"), []byte(testData), 0o644))
+
+	var buf bytes.Buffer
+	cipher, err := NewDataWriter(&buf)
+	checkError(t, err)
+	checkError(t, ctx.EncryptDir([]*Key{key}, EncryptAlwaysTrust, srcDir, []string{"tree"}, cipher))
+	if buf.Len() < 1 {
+		t.Fatal("Expected encrypted archive, got empty buffer")
+	}
+
+	dstDir, err := ioutil.TempDir("", "gpgme-archive-dst")
+	checkError(t, err)
+	defer os.RemoveAll(dstDir)
+	cipher, err = NewDataBytes(buf.Bytes())
+	checkError(t, err)
+	ctx.SetMaxPlaintextBytes(1)
+	checkError(t, ctx.DecryptToDir(cipher, dstDir))
+	if max := ctx.MaxPlaintextBytes(); max != 1 {
+		t.Errorf("Expected DecryptToDir to keep the plaintext limit 1, got %d", max)
+	}
+
+	got, err := ioutil.ReadFile(filepath.Join(dstDir, "tree", "sub", "file.txt"))
+	checkError(t, err)
+	diff(t, got, []byte(testData))
+}
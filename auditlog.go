@@ -0,0 +1,105 @@
+package gpgme
+
+// #include <gpgme.h>
+import "C"
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"runtime"
+	"strings"
+)
+
+// AuditLogFlag specifies the format of the audit log
+type AuditLogFlag uint
+
+const (
+	AuditLogDefault AuditLogFlag = C.GPGME_AUDITLOG_DEFAULT
+	AuditLogHTML    AuditLogFlag = C.GPGME_AUDITLOG_HTML
+	// AuditLogDiag returns the diagnostic output of the last operation
+	// instead of the audit log
+	AuditLogDiag     AuditLogFlag = C.GPGME_AUDITLOG_DIAG
+	AuditLogWithHelp AuditLogFlag = C.GPGME_AUDITLOG_WITH_HELP
+)
+
+// GetAuditLog writes the audit log of the last operation of the context to
+// output. Audit logs are mostly provided by the CMS engine and explain the
+// individual checks of a signature or certificate chain validation; with
+// AuditLogDiag the diagnostic output of gpg is also available for OpenPGP.
+func (c *Context) GetAuditLog(output *Data, flags AuditLogFlag) error {
+	err := handleError(C.gpgme_op_getauditlog(c.ctx, output.dh, C.uint(flags)))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(output)
+	return err
+}
+
+// AuditLogEntry is one check of an audit log
+type AuditLogEntry struct {
+	// Text describes the check, e.g. "Data verification succeeded"
+	Text string
+	// Result is the outcome of the check, e.g. "Yes", "No" or "Good", if
+	// given
+	Result string
+	// Children are the checks the entry consists of
+	Children []AuditLogEntry
+}
+
+// AuditLog returns the audit log of the last operation of the context as a
+// tree of entries, see ParseAuditLog.
+func (c *Context) AuditLog() ([]AuditLogEntry, error) {
+	var buf bytes.Buffer
+	out, err := NewDataWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+	defer out.Close()
+	if err := c.GetAuditLog(out, AuditLogDefault); err != nil {
+		return nil, err
+	}
+	return ParseAuditLog(&buf)
+}
+
+// ParseAuditLog parses an audit log in the default text format, in which
+// each check is a line of the form "* text: result", indented according to
+// its nesting.
+func ParseAuditLog(r io.Reader) ([]AuditLogEntry, error) {
+	var items []auditLogItem
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimRight(s.Text(), " \t\r")
+		text := strings.TrimLeft(line, " \t")
+		if text == "" {
+			continue
+		}
+		item := auditLogItem{indent: len(line) - len(text)}
+		text = strings.TrimSpace(strings.TrimLeft(text, "*-"))
+		item.entry.Text = text
+		if i := strings.LastIndex(text, ": "); i >= 0 {
+			item.entry.Text, item.entry.Result = text[:i], strings.TrimSpace(text[i+2:])
+		}
+		items = append(items, item)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	entries, _ := auditLogTree(items, -1)
+	return entries, nil
+}
+
+type auditLogItem struct {
+	indent int
+	entry  AuditLogEntry
+}
+
+// auditLogTree returns the entries of items indented deeper than parent,
+// with their children, and the remaining items
+func auditLogTree(items []auditLogItem, parent int) ([]AuditLogEntry, []auditLogItem) {
+	var entries []AuditLogEntry
+	for len(items) > 0 && items[0].indent > parent {
+		item := items[0]
+		item.entry.Children, items = auditLogTree(items[1:], item.indent)
+		entries = append(entries, item.entry)
+	}
+	return entries, items
+}
@@ -0,0 +1,35 @@
+package gpgme
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAuditLog(t *testing.T) {
+	const log = `* Data verification succeeded: Yes
+  * Data available: Yes
+  * Signature available: Yes
+  * Parsing data succeeded: Yes
+    * Signature 0
+      * Certificate chain valid: No
+* Gpg-Agent usable: Yes
+`
+	entries, err := ParseAuditLog(strings.NewReader(log))
+	checkError(t, err)
+	want := []AuditLogEntry{
+		{Text: "Data verification succeeded", Result: "Yes", Children: []AuditLogEntry{
+			{Text: "Data available", Result: "Yes"},
+			{Text: "Signature available", Result: "Yes"},
+			{Text: "Parsing data succeeded", Result: "Yes", Children: []AuditLogEntry{
+				{Text: "Signature 0", Children: []AuditLogEntry{
+					{Text: "Certificate chain valid", Result: "No"},
+				}},
+			}},
+		}},
+		{Text: "Gpg-Agent usable", Result: "Yes"},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("ParseAuditLog() = %+v, want %+v", entries, want)
+	}
+}
@@ -0,0 +1,125 @@
+package gpgme
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// FileJob names a file to process and where to write the result
+type FileJob struct {
+	Src string
+	Dst string
+}
+
+// FileError describes the failure of one FileJob
+type FileError struct {
+	Path string
+	Err  error
+}
+
+func (e *FileError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// FileErrors is returned by EncryptFiles when one or more files failed
+type FileErrors []*FileError
+
+func (e FileErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// EncryptFiles encrypts each job's Src for recipients into a new file Dst,
+// using workers contexts concurrently. Failed jobs don't stop the others;
+// their partial output is removed and the error is a FileErrors in the
+// order of jobs.
+//
+// If progress is not nil it is called after each job with the number of jobs
+// done so far; calls are not concurrent.
+func EncryptFiles(recipients []*Key, jobs []FileJob, workers int, progress func(done, total int)) error {
+	if workers < 1 {
+		workers = 1
+	}
+	errs := make([]error, len(jobs))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex // serializes progress
+	done := 0
+	for w := 0; w < workers && w < len(jobs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, err := New()
+			if ctx != nil {
+				defer ctx.Release()
+			}
+			for i := range next {
+				if err == nil {
+					errs[i] = encryptFile(ctx, recipients, jobs[i])
+				} else {
+					errs[i] = err
+				}
+				if progress != nil {
+					mu.Lock()
+					done++
+					progress(done, len(jobs))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for i := range jobs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	var ferrs FileErrors
+	for i, err := range errs {
+		if err != nil {
+			ferrs = append(ferrs, &FileError{Path: jobs[i].Src, Err: err})
+		}
+	}
+	if ferrs != nil {
+		return ferrs
+	}
+	return nil
+}
+
+func encryptFile(ctx *Context, recipients []*Key, job FileJob) error {
+	src, err := os.Open(job.Src)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(job.Dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	plain, err := NewDataFile(src)
+	if err == nil {
+		defer plain.Close()
+		var cipher *Data
+		cipher, err = NewDataFile(dst)
+		if err == nil {
+			err = ctx.Encrypt(recipients, 0, plain, cipher)
+			cipher.Close()
+		}
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(job.Dst)
+	}
+	return err
+}
@@ -0,0 +1,45 @@
+package gpgme
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEncryptFiles(t *testing.T) {
+	keys, err := FindKeys("test@example.com", false)
+	checkError(t, err)
+
+	dir, err := ioutil.TempDir("", "gpgme-bulk")
+	checkError(t, err)
+	defer os.RemoveAll(dir)
+	var jobs []FileJob
+	for _, name := range []string{"a", "b", "c", "missing"} {
+		src := filepath.Join(dir, name)
+		if name != "missing" {
+			checkError(t, ioutil.WriteFile(src, []byte(testData), 0o644))
+		}
+		jobs = append(jobs, FileJob{Src: src, Dst: src + ".gpg"})
+	}
+
+	var last int
+	err = EncryptFiles(keys, jobs, 2, func(done, total int) {
+		if total != len(jobs) || done != last+1 {
+			t.Errorf("Unexpected progress %d/%d", done, total)
+		}
+		last = done
+	})
+	ferrs, ok := err.(FileErrors)
+	if !ok || len(ferrs) != 1 || ferrs[0].Path != jobs[3].Src {
+		t.Fatalf("Expected error for missing file, got %v", err)
+	}
+	for _, job := range jobs[:3] {
+		if fi, err := os.Stat(job.Dst); err != nil || fi.Size() == 0 {
+			t.Errorf("Expected encrypted output %s: %v", job.Dst, err)
+		}
+	}
+	if _, err := os.Stat(jobs[3].Dst); !os.IsNotExist(err) {
+		t.Errorf("Expected no output for missing file, got %v", err)
+	}
+}
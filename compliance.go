@@ -0,0 +1,71 @@
+package gpgme
+
+// #include <stdlib.h>
+// #include <gpgme.h>
+// #include "go_gpgme.h"
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// ComplianceDeVs is the compliance mode for classified information in
+// Germany at the restricted level (VS-NfD). In this mode the IsDeVs fields of
+// DecryptResult, Signature and SubKey tell whether an operation or key meets
+// the rules.
+const ComplianceDeVs = "de-vs"
+
+// Compliance returns the compliance mode of the OpenPGP engine of the
+// context, e.g. "gnupg" or ComplianceDeVs, as configured with the compliance
+// option of gpg. It is queried with the gpgconf engine of GPGME.
+func (c *Context) Compliance() (string, error) {
+	conf, err := c.newGPGConfContext()
+	if err != nil {
+		return "", err
+	}
+	defer conf.Release()
+
+	var comps C.gpgme_conf_comp_t
+	err = handleError(C.gpgme_op_conf_load(conf.ctx, &comps))
+	runtime.KeepAlive(conf)
+	if err != nil {
+		return "", err
+	}
+	defer C.gpgme_conf_release(comps)
+
+	ccomp := C.CString("gpg")
+	defer C.free(unsafe.Pointer(ccomp))
+	cname := C.CString("compliance")
+	defer C.free(unsafe.Pointer(cname))
+	if v := C.GoString(C.gogpgme_conf_string(comps, ccomp, cname)); v != "" {
+		return v, nil
+	}
+	return "gnupg", nil
+}
+
+// newGPGConfContext returns a new context for the gpgconf engine, which
+// implements the configuration and software version queries, using the
+// home directory of the OpenPGP engine of c.
+func (c *Context) newGPGConfContext() (*Context, error) {
+	conf, err := New()
+	if err != nil {
+		return nil, err
+	}
+	if err := conf.SetProtocol(ProtocolGPGConf); err != nil {
+		conf.Release()
+		return nil, err
+	}
+	info := c.EngineInfo()
+	if pgp := info.ForProtocol(ProtocolOpenPGP); pgp != nil && pgp.HomeDir() != "" {
+		var fileName string
+		if gpgconf := info.ForProtocol(ProtocolGPGConf); gpgconf != nil {
+			fileName = gpgconf.FileName()
+		}
+		if err := conf.SetEngineInfo(ProtocolGPGConf, fileName, pgp.HomeDir()); err != nil {
+			conf.Release()
+			return nil, err
+		}
+	}
+	return conf, nil
+}
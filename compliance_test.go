@@ -0,0 +1,31 @@
+package gpgme
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestContext_Compliance(t *testing.T) {
+	ensureVersion(t, "2.", "compliance modes require GPG v2.x")
+
+	ctx, err := New()
+	checkError(t, err)
+	mode, err := ctx.Compliance()
+	checkError(t, err)
+	if mode == "" {
+		t.Error("Expected a compliance mode")
+	}
+}
+
+func TestContext_Compliance_homeDir(t *testing.T) {
+	ctx := tempHomeContext(t)
+	homeDir := ctx.EngineInfo().ForProtocol(ProtocolOpenPGP).HomeDir()
+	checkError(t, ioutil.WriteFile(filepath.Join(homeDir, "gpg.conf"), []byte("compliance openpgp\n"), 0o600))
+
+	mode, err := ctx.Compliance()
+	checkError(t, err)
+	if mode != "openpgp" {
+		t.Errorf("Compliance() = %q, want openpgp", mode)
+	}
+}
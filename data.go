@@ -1,5 +1,6 @@
 package gpgme
 
+// #include <stdlib.h>
 // #include <string.h>
 // #include <gpgme.h>
 // #include <errno.h>
@@ -7,10 +8,14 @@ package gpgme
 import "C"
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"runtime"
 	"runtime/cgo"
+	"strconv"
 	"unsafe"
 )
 
@@ -73,6 +78,8 @@ type Data struct {
 	s   io.Seeker
 	cbc cgo.Handle // WARNING: Call runtime.KeepAlive(d) after ANY use of d.cbc in C (typically via d.dh)
 	err error
+
+	onClose func() error // see OnClose
 }
 
 func newData() *Data {
@@ -94,6 +101,25 @@ func NewDataFile(f *os.File) (*Data, error) {
 	return d, handleError(C.gpgme_data_new_from_fd(&d.dh, C.int(f.Fd())))
 }
 
+// NewDataFD returns a new data buffer reading from and writing to the file
+// descriptor fd, such as a socket or a pipe from another process. The
+// descriptor is used directly by GPGME without going through Go; it is not
+// closed by Close and must stay open until then.
+func NewDataFD(fd int) (*Data, error) {
+	d := newData()
+	return d, handleError(C.gpgme_data_new_from_fd(&d.dh, C.int(fd)))
+}
+
+// NewDataFilePart returns a new memory based data buffer holding length bytes
+// of the file at path starting at offset, e.g. a signature embedded at a known
+// position in a larger file.
+func NewDataFilePart(path string, offset int64, length int) (*Data, error) {
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+	d := newData()
+	return d, handleError(C.gpgme_data_new_from_filepart(&d.dh, cpath, nil, C.gpgme_off_t(offset), C.size_t(length)))
+}
+
 // NewDataBytes returns a new memory based data buffer that contains `b` bytes
 func NewDataBytes(b []byte) (*Data, error) {
 	d := newData()
@@ -104,6 +130,38 @@ func NewDataBytes(b []byte) (*Data, error) {
 	return d, handleError(C.gpgme_data_new_from_mem(&d.dh, cb, C.size_t(len(b)), 1))
 }
 
+// NewDataBytesNoCopy is like NewDataBytes but reads b through callbacks
+// instead of copying it into GPGME, avoiding doubling the memory needed for
+// large buffers. b must not be modified until the data buffer is closed.
+func NewDataBytesNoCopy(b []byte) (*Data, error) {
+	return NewDataReader(bytes.NewReader(b))
+}
+
+// defaultIOBufferSize is the size of the buffer GPGME uses for new callback
+// based data buffers, bounding the amount of data passed per callback, see
+// SetIOBufferSize
+const defaultIOBufferSize = 64 << 10
+
+// initCallbacks creates the callback based GPGME data of d
+func (d *Data) initCallbacks() error {
+	d.cbc = cgo.NewHandle(d)
+	if err := handleError(C.gpgme_data_new_from_cbs(&d.dh, &dataCallbacks, unsafe.Pointer(&d.cbc))); err != nil {
+		return err
+	}
+	err := d.SetIOBufferSize(defaultIOBufferSize)
+	if isUnknownName(err) {
+		// Older GPGME versions don't know the flag and keep their default
+		return nil
+	}
+	return err
+}
+
+// isUnknownName reports whether err is the GPGME error for an unknown flag
+func isUnknownName(err error) bool {
+	var e Error
+	return errors.As(err, &e) && e.Code() == ErrorCode(C.GPG_ERR_UNKNOWN_NAME)
+}
+
 // NewDataReader returns a new callback based data buffer
 func NewDataReader(r io.Reader) (*Data, error) {
 	d := newData()
@@ -111,8 +169,7 @@ func NewDataReader(r io.Reader) (*Data, error) {
 	if s, ok := r.(io.Seeker); ok {
 		d.s = s
 	}
-	d.cbc = cgo.NewHandle(d)
-	return d, handleError(C.gpgme_data_new_from_cbs(&d.dh, &dataCallbacks, unsafe.Pointer(&d.cbc)))
+	return d, d.initCallbacks()
 }
 
 // NewDataWriter returns a new callback based data buffer
@@ -122,8 +179,7 @@ func NewDataWriter(w io.Writer) (*Data, error) {
 	if s, ok := w.(io.Seeker); ok {
 		d.s = s
 	}
-	d.cbc = cgo.NewHandle(d)
-	return d, handleError(C.gpgme_data_new_from_cbs(&d.dh, &dataCallbacks, unsafe.Pointer(&d.cbc)))
+	return d, d.initCallbacks()
 }
 
 // NewDataReadWriter returns a new callback based data buffer
@@ -134,8 +190,7 @@ func NewDataReadWriter(rw io.ReadWriter) (*Data, error) {
 	if s, ok := rw.(io.Seeker); ok {
 		d.s = s
 	}
-	d.cbc = cgo.NewHandle(d)
-	return d, handleError(C.gpgme_data_new_from_cbs(&d.dh, &dataCallbacks, unsafe.Pointer(&d.cbc)))
+	return d, d.initCallbacks()
 }
 
 // NewDataReadWriteSeeker returns a new callback based data buffer
@@ -144,8 +199,27 @@ func NewDataReadWriteSeeker(rw io.ReadWriteSeeker) (*Data, error) {
 	d.r = rw
 	d.w = rw
 	d.s = rw
-	d.cbc = cgo.NewHandle(d)
-	return d, handleError(C.gpgme_data_new_from_cbs(&d.dh, &dataCallbacks, unsafe.Pointer(&d.cbc)))
+	return d, d.initCallbacks()
+}
+
+// NewDataReadWriteCloser returns a new callback based data buffer that
+// closes rw when it is closed
+func NewDataReadWriteCloser(rw io.ReadWriteCloser) (*Data, error) {
+	d := newData()
+	d.r = rw
+	d.w = rw
+	if s, ok := rw.(io.Seeker); ok {
+		d.s = s
+	}
+	d.onClose = rw.Close
+	return d, d.initCallbacks()
+}
+
+// OnClose sets fn to be called when the data buffer is closed, after GPGME
+// has released it, e.g. to remove a temporary file backing it. The error of
+// fn is returned by Close. It replaces a function set before.
+func (d *Data) OnClose(fn func() error) {
+	d.onClose = fn
 }
 
 // Close releases any resources associated with the data buffer
@@ -159,6 +233,12 @@ func (d *Data) Close() error {
 	_, err := C.gpgme_data_release(d.dh)
 	runtime.KeepAlive(d)
 	d.dh = nil
+	if fn := d.onClose; fn != nil {
+		d.onClose = nil
+		if cerr := fn(); err == nil {
+			err = cerr
+		}
+	}
 	return err
 }
 
@@ -204,6 +284,60 @@ func (d *Data) Read(p []byte) (int, error) {
 	return int(n), nil
 }
 
+// dataChunkSize is the buffer size of WriteTo and ReadFrom. Each chunk costs
+// a cgo call, so it is much larger than the default of io.Copy.
+const dataChunkSize = 1 << 20
+
+// WriteTo writes the data from the current position to w, implementing
+// io.WriterTo for io.Copy.
+func (d *Data) WriteTo(w io.Writer) (int64, error) {
+	buf := make([]byte, dataChunkSize)
+	var total int64
+	for {
+		n, err := d.Read(buf)
+		if n > 0 {
+			m, werr := w.Write(buf[:n])
+			total += int64(m)
+			if werr != nil {
+				return total, werr
+			}
+			if m != n {
+				return total, io.ErrShortWrite
+			}
+		}
+		if err == io.EOF {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+// ReadFrom writes the data read from r until EOF, implementing io.ReaderFrom
+// for io.Copy.
+func (d *Data) ReadFrom(r io.Reader) (int64, error) {
+	buf := make([]byte, dataChunkSize)
+	var total int64
+	for {
+		n, err := r.Read(buf)
+		for p := buf[:n]; len(p) > 0; {
+			m, werr := d.Write(p)
+			total += int64(m)
+			if werr != nil {
+				return total, werr
+			}
+			p = p[m:]
+		}
+		if err == io.EOF {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
 func (d *Data) Seek(offset int64, whence int) (int64, error) {
 	n, err := C.gogpgme_data_seek(d.dh, C.gpgme_off_t(offset), C.int(whence))
 	runtime.KeepAlive(d)
@@ -224,3 +358,132 @@ func (d *Data) Name() string {
 	runtime.KeepAlive(d)
 	return res
 }
+
+// SetName sets the associated filename, which is stored in OpenPGP messages
+// and, for archive operations, names the base directory
+func (d *Data) SetName(name string) error {
+	var cname *C.char
+	if name != "" {
+		cname = C.CString(name)
+		defer C.free(unsafe.Pointer(cname))
+	}
+	err := handleError(C.gpgme_data_set_file_name(d.dh, cname))
+	runtime.KeepAlive(d)
+	return err
+}
+
+// SetIOBufferSize sets the size of the buffer GPGME uses for a callback
+// based data buffer, up to 1 MiB. It must be called before the data is used
+// and requires GPGME 1.23 or later.
+func (d *Data) SetIOBufferSize(size int) error {
+	return d.setFlag("io-buffer-size", strconv.Itoa(size))
+}
+
+// SetSizeHint tells the engine the expected total size of the data, so it
+// can report accurate progress for data it cannot seek to determine the
+// size.
+func (d *Data) SetSizeHint(size int64) error {
+	return d.setFlag("size-hint", strconv.FormatInt(size, 10))
+}
+
+func (d *Data) setFlag(name, value string) error {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	cvalue := C.CString(value)
+	defer C.free(unsafe.Pointer(cvalue))
+	err := handleError(C.gpgme_data_set_flag(d.dh, cname, cvalue))
+	runtime.KeepAlive(d)
+	return err
+}
+
+// DataEncoding is the encoding of the content of a data buffer
+type DataEncoding int
+
+const (
+	// DataEncodingNone leaves the encoding to be detected by the engine
+	DataEncodingNone   DataEncoding = C.GPGME_DATA_ENCODING_NONE
+	DataEncodingBinary DataEncoding = C.GPGME_DATA_ENCODING_BINARY
+	DataEncodingBase64 DataEncoding = C.GPGME_DATA_ENCODING_BASE64
+	DataEncodingArmor  DataEncoding = C.GPGME_DATA_ENCODING_ARMOR
+	// DataEncodingURL is a list of line feed separated URLs
+	DataEncodingURL DataEncoding = C.GPGME_DATA_ENCODING_URL
+	// DataEncodingURLEsc is like DataEncodingURL with percent-escaped
+	// spaces
+	DataEncodingURLEsc DataEncoding = C.GPGME_DATA_ENCODING_URLESC
+	// DataEncodingURL0 is a list of NUL separated URLs
+	DataEncodingURL0 DataEncoding = C.GPGME_DATA_ENCODING_URL0
+	DataEncodingMIME DataEncoding = C.GPGME_DATA_ENCODING_MIME
+)
+
+// Encoding returns the encoding of the data
+func (d *Data) Encoding() DataEncoding {
+	res := DataEncoding(C.gpgme_data_get_encoding(d.dh))
+	runtime.KeepAlive(d)
+	return res
+}
+
+// SetEncoding sets the encoding of the data. For input it tells the engine
+// how the content is encoded instead of having it guessed; for output it is a
+// hint to engines that honor it.
+func (d *Data) SetEncoding(enc DataEncoding) error {
+	err := handleError(C.gpgme_data_set_encoding(d.dh, C.gpgme_data_encoding_t(enc)))
+	runtime.KeepAlive(d)
+	return err
+}
+
+// DataType is the kind of content of a data buffer, see Identify
+type DataType int
+
+const (
+	DataTypeInvalid      DataType = C.GPGME_DATA_TYPE_INVALID
+	DataTypeUnknown      DataType = C.GPGME_DATA_TYPE_UNKNOWN
+	DataTypePGPSigned    DataType = C.GPGME_DATA_TYPE_PGP_SIGNED
+	DataTypePGPEncrypted DataType = C.GPGME_DATA_TYPE_PGP_ENCRYPTED
+	DataTypePGPOther     DataType = C.GPGME_DATA_TYPE_PGP_OTHER
+	DataTypePGPKey       DataType = C.GPGME_DATA_TYPE_PGP_KEY
+	DataTypePGPSignature DataType = C.GPGME_DATA_TYPE_PGP_SIGNATURE
+	DataTypeCMSSigned    DataType = C.GPGME_DATA_TYPE_CMS_SIGNED
+	DataTypeCMSEncrypted DataType = C.GPGME_DATA_TYPE_CMS_ENCRYPTED
+	DataTypeCMSOther     DataType = C.GPGME_DATA_TYPE_CMS_OTHER
+	DataTypeX509Cert     DataType = C.GPGME_DATA_TYPE_X509_CERT
+	DataTypePKCS12       DataType = C.GPGME_DATA_TYPE_PKCS12
+)
+
+func (t DataType) String() string {
+	switch t {
+	case DataTypeInvalid:
+		return "invalid"
+	case DataTypeUnknown:
+		return "unknown"
+	case DataTypePGPSigned:
+		return "PGP signed"
+	case DataTypePGPEncrypted:
+		return "PGP encrypted"
+	case DataTypePGPOther:
+		return "PGP other"
+	case DataTypePGPKey:
+		return "PGP key"
+	case DataTypePGPSignature:
+		return "PGP signature"
+	case DataTypeCMSSigned:
+		return "CMS signed"
+	case DataTypeCMSEncrypted:
+		return "CMS encrypted"
+	case DataTypeCMSOther:
+		return "CMS other"
+	case DataTypeX509Cert:
+		return "X.509 certificate"
+	case DataTypePKCS12:
+		return "PKCS#12"
+	}
+	return fmt.Sprintf("DataType(%d)", int(t))
+}
+
+// Identify inspects the start of the data to tell what it contains. The data
+// must be seekable; the read position is restored afterwards. DataTypeInvalid
+// is returned if the data could not be read.
+func (d *Data) Identify() DataType {
+	res := DataType(C.gpgme_data_identify(d.dh, 0))
+	runtime.KeepAlive(d)
+	return res
+}
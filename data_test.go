@@ -38,6 +38,17 @@ func TestData_memory(t *testing.T) {
 	}
 }
 
+func TestData_memoryNoCopy(t *testing.T) {
+	for _, content := range [][]byte{[]byte(testCipherText), []byte{}} {
+		dh, err := NewDataBytesNoCopy(content)
+		checkError(t, err)
+
+		testReader(t, dh, content)
+
+		checkError(t, dh.Close())
+	}
+}
+
 func TestData_file(t *testing.T) {
 	f, err := ioutil.TempFile("", "gpgme")
 	checkError(t, err)
@@ -62,6 +73,38 @@ func TestData_file(t *testing.T) {
 	checkError(t, dh.Close())
 }
 
+func TestData_filePart(t *testing.T) {
+	f, err := ioutil.TempFile("", "gpgme")
+	checkError(t, err)
+	defer func() {
+		checkError(t, f.Close())
+		checkError(t, os.Remove(f.Name()))
+	}()
+	_, err = f.WriteString("header" + testCipherText + "trailer")
+	checkError(t, err)
+
+	dh, err := NewDataFilePart(f.Name(), int64(len("header")), len(testCipherText))
+	checkError(t, err)
+	testReader(t, dh, []byte(testCipherText))
+	checkError(t, dh.Close())
+}
+
+func TestData_fd(t *testing.T) {
+	r, w, err := os.Pipe()
+	checkError(t, err)
+	defer r.Close()
+
+	go func() {
+		_, _ = w.Write([]byte(testCipherText))
+		w.Close()
+	}()
+
+	dh, err := NewDataFD(int(r.Fd()))
+	checkError(t, err)
+	testReader(t, dh, []byte(testCipherText))
+	checkError(t, dh.Close())
+}
+
 func TestData_callback_reading(t *testing.T) {
 	r := bytes.NewReader([]byte(testCipherText))
 	dh, err := NewDataReader(r)
@@ -72,6 +115,18 @@ func TestData_callback_reading(t *testing.T) {
 	checkError(t, dh.Close())
 }
 
+func TestData_SetIOBufferSize(t *testing.T) {
+	content := bytes.Repeat([]byte(testCipherText), 100)
+	dh, err := NewDataReader(bytes.NewReader(content))
+	checkError(t, err)
+	defer dh.Close()
+
+	if err := dh.SetIOBufferSize(1 << 20); err != nil {
+		t.Skip("io-buffer-size not supported:", err)
+	}
+	testReader(t, dh, content)
+}
+
 func TestData_callback_reading_error(t *testing.T) {
 	expectedErr := errors.New("a special error")
 	r := errReadSeeker{err: expectedErr}
@@ -129,6 +184,61 @@ func TestData_callback_writing_error(t *testing.T) {
 	checkError(t, dh.Close())
 }
 
+func TestData_ReadFromWriteTo(t *testing.T) {
+	content := bytes.Repeat([]byte(testCipherText), 5000)
+	dh, err := NewData()
+	checkError(t, err)
+	defer dh.Close()
+
+	n, err := dh.ReadFrom(bytes.NewReader(content))
+	checkError(t, err)
+	if int(n) != len(content) {
+		t.Errorf("ReadFrom n = %d, want %d", n, len(content))
+	}
+	_, err = dh.Seek(0, SeekSet)
+	checkError(t, err)
+	var buf bytes.Buffer
+	n, err = dh.WriteTo(&buf)
+	checkError(t, err)
+	if int(n) != len(content) {
+		t.Errorf("WriteTo n = %d, want %d", n, len(content))
+	}
+	diff(t, buf.Bytes(), content)
+}
+
+type closeRecorder struct {
+	bytes.Buffer
+	closed int
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestData_ReadWriteCloser(t *testing.T) {
+	rw := &closeRecorder{}
+	dh, err := NewDataReadWriteCloser(rw)
+	checkError(t, err)
+
+	_, err = dh.Write([]byte(testData))
+	checkError(t, err)
+	checkError(t, dh.Close())
+	checkError(t, dh.Close())
+	if rw.closed != 1 {
+		t.Errorf("Expected underlying closer to be closed once, got %d", rw.closed)
+	}
+	diff(t, rw.Bytes(), []byte(testData))
+
+	expectedErr := errors.New("a special error")
+	dh, err = NewData()
+	checkError(t, err)
+	dh.OnClose(func() error { return expectedErr })
+	if err := dh.Close(); err != expectedErr {
+		t.Errorf("err = %v, want %v", err, expectedErr)
+	}
+}
+
 func testReader(t testing.TB, r io.Reader, content []byte) {
 	var buf bytes.Buffer
 	n, err := io.Copy(&buf, r)
@@ -156,3 +266,58 @@ func (rs errReadSeeker) Read([]byte) (int, error) {
 func (rs errReadSeeker) Seek(int64, int) (int64, error) {
 	return 0, rs.err
 }
+
+func TestData_SetName(t *testing.T) {
+	dh, err := NewData()
+	checkError(t, err)
+	defer dh.Close()
+
+	checkError(t, dh.SetName("message.txt"))
+	if name := dh.Name(); name != "message.txt" {
+		t.Errorf("Name() = %q, want message.txt", name)
+	}
+	checkError(t, dh.SetName(""))
+	if name := dh.Name(); name != "" {
+		t.Errorf("Name() = %q, want empty", name)
+	}
+}
+
+func TestData_SetSizeHint(t *testing.T) {
+	dh, err := NewDataReader(bytes.NewBufferString(testData))
+	checkError(t, err)
+	defer dh.Close()
+
+	checkError(t, dh.SetSizeHint(int64(len(testData))))
+}
+
+func TestData_Identify(t *testing.T) {
+	for _, tt := range []struct {
+		content string
+		want    DataType
+	}{
+		{testCipherText, DataTypePGPEncrypted},
+		{testSignedText, DataTypePGPSigned},
+		{"Test message\n", DataTypeUnknown},
+	} {
+		dh, err := NewDataBytes([]byte(tt.content))
+		checkError(t, err)
+		if got := dh.Identify(); got != tt.want {
+			t.Errorf("Identify() = %s, want %s", got, tt.want)
+		}
+		checkError(t, dh.Close())
+	}
+}
+
+func TestData_Encoding(t *testing.T) {
+	dh, err := NewData()
+	checkError(t, err)
+	defer dh.Close()
+
+	if enc := dh.Encoding(); enc != DataEncodingNone {
+		t.Errorf("Expected no encoding, got %d", enc)
+	}
+	checkError(t, dh.SetEncoding(DataEncodingArmor))
+	if enc := dh.Encoding(); enc != DataEncodingArmor {
+		t.Errorf("Expected armor encoding, got %d", enc)
+	}
+}
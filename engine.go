@@ -0,0 +1,102 @@
+package gpgme
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// EngineError describes why the engine of a protocol is not usable, see
+// CheckEngine.
+type EngineError struct {
+	Protocol Protocol
+	// FileName is the configured engine executable, empty if there is none.
+	FileName string
+	// Version is the version of the engine, empty if it could not be run.
+	Version         string
+	RequiredVersion string
+	// NotFound reports that there is no executable at FileName.
+	NotFound bool
+	// TooOld reports that Version is older than RequiredVersion.
+	TooOld bool
+	// Err is the error of GPGME.
+	Err error
+}
+
+func (e *EngineError) Error() string {
+	name := e.Protocol.String() + " engine"
+	if e.FileName != "" {
+		name = filepath.Base(e.FileName)
+	}
+	switch {
+	case e.FileName == "":
+		return fmt.Sprintf("%s not available: %v", name, e.Err)
+	case e.NotFound:
+		return fmt.Sprintf("%s executable not found at %s: %v", name, e.FileName, e.Err)
+	case e.Version == "":
+		return fmt.Sprintf("%s executable at %s could not be run: %v", name, e.FileName, e.Err)
+	case e.TooOld:
+		return fmt.Sprintf("%s version %s < required %s: %v", name, e.Version, e.RequiredVersion, e.Err)
+	}
+	return fmt.Sprintf("%s version %s: %v", name, e.Version, e.Err)
+}
+
+func (e *EngineError) Unwrap() error {
+	return e.Err
+}
+
+// CheckEngine checks that the default engine of proto is installed in a
+// version supported by GPGME, as EngineCheckVersion. If it is not, the error
+// is an *EngineError describing the engine found.
+func CheckEngine(proto Protocol) error {
+	err := EngineCheckVersion(proto)
+	if err == nil {
+		return nil
+	}
+	engineErr := &EngineError{Protocol: proto, Err: err}
+	if info, infoErr := GetEngineInfo(); infoErr == nil {
+		if info := info.ForProtocol(proto); info != nil {
+			engineErr.FileName = info.FileName()
+			engineErr.Version = info.Version()
+			engineErr.RequiredVersion = info.RequiredVersion()
+		}
+	}
+	if engineErr.FileName != "" && engineErr.Version == "" {
+		_, statErr := os.Stat(engineErr.FileName)
+		engineErr.NotFound = os.IsNotExist(statErr)
+	}
+	if engineErr.Version != "" && engineErr.RequiredVersion != "" {
+		engineErr.TooOld = versionLess(engineErr.Version, engineErr.RequiredVersion)
+	}
+	return engineErr
+}
+
+// versionLess reports whether the dotted version v is older than w, comparing
+// the leading digits of each part, so "2.2.4-beta" is read as 2.2.4.
+func versionLess(v, w string) bool {
+	vs, ws := strings.Split(v, "."), strings.Split(w, ".")
+	for i := 0; i < len(vs) || i < len(ws); i++ {
+		var a, b int
+		if i < len(vs) {
+			a = leadingNumber(vs[i])
+		}
+		if i < len(ws) {
+			b = leadingNumber(ws[i])
+		}
+		if a != b {
+			return a < b
+		}
+	}
+	return false
+}
+
+func leadingNumber(s string) int {
+	end := 0
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	n, _ := strconv.Atoi(s[:end])
+	return n
+}
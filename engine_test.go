@@ -0,0 +1,75 @@
+package gpgme
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckEngine(t *testing.T) {
+	checkError(t, CheckEngine(ProtocolOpenPGP))
+
+	testProto := ProtocolOpenPGP // Careful, this is global state!
+	defer func() {
+		_ = SetEngineInfo(testProto, "", "") // Try to reset to defaults after we are done.
+	}()
+	missing := filepath.Join(t.TempDir(), "gpg")
+	checkError(t, SetEngineInfo(testProto, missing, ""))
+
+	err := CheckEngine(testProto)
+	var engineErr *EngineError
+	if !errors.As(err, &engineErr) {
+		t.Fatalf("Expected *EngineError, got %v", err)
+	}
+	if engineErr.FileName != missing {
+		t.Errorf("FileName = %q, want %q", engineErr.FileName, missing)
+	}
+	if !engineErr.NotFound {
+		t.Error("Expected NotFound")
+	}
+	if want := "gpg executable not found at " + missing + ": " + engineErr.Err.Error(); err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestEngineError_Error(t *testing.T) {
+	gpgmeErr := errors.New("invalid crypto engine")
+	for _, tt := range []struct {
+		err  EngineError
+		want string
+	}{
+		{EngineError{Protocol: ProtocolOpenPGP, FileName: "/usr/bin/gpg", Version: "2.0.22", RequiredVersion: "2.1", TooOld: true, Err: gpgmeErr},
+			"gpg version 2.0.22 < required 2.1: invalid crypto engine"},
+		{EngineError{Protocol: ProtocolOpenPGP, FileName: "/usr/bin/gpg", Version: "2.2.4", RequiredVersion: "2.1", Err: gpgmeErr},
+			"gpg version 2.2.4: invalid crypto engine"},
+		{EngineError{Protocol: ProtocolOpenPGP, FileName: "/usr/bin/gpg", NotFound: true, Err: gpgmeErr},
+			"gpg executable not found at /usr/bin/gpg: invalid crypto engine"},
+		{EngineError{Protocol: ProtocolOpenPGP, FileName: "/usr/bin/gpg", Err: gpgmeErr},
+			"gpg executable at /usr/bin/gpg could not be run: invalid crypto engine"},
+	} {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+		if !errors.Is(&tt.err, gpgmeErr) {
+			t.Errorf("Expected %v to wrap %v", &tt.err, gpgmeErr)
+		}
+	}
+}
+
+func TestVersionLess(t *testing.T) {
+	for _, tt := range []struct {
+		v, w string
+		want bool
+	}{
+		{"2.0.22", "2.1", true},
+		{"2.1", "2.0.22", false},
+		{"2.10.0", "2.9", false},
+		{"2.1", "2.1.0", false},
+		{"2.2.4-beta", "2.2.5", true},
+		{"1.17.1", "1.17.1", false},
+	} {
+		if got := versionLess(tt.v, tt.w); got != tt.want {
+			t.Errorf("versionLess(%q, %q) = %v, want %v", tt.v, tt.w, got, tt.want)
+		}
+	}
+}
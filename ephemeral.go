@@ -0,0 +1,95 @@
+package gpgme
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+)
+
+// WithTemporaryHome points the OpenPGP and CMS engines of c at a new, empty
+// home directory only accessible by the current user, e.g. to verify
+// signatures against a fixed set of imported keys. The returned function
+// restores the previous home directories, stops the agent started for the
+// temporary one and removes it.
+func (c *Context) WithTemporaryHome() (homeDir string, cleanup func() error, err error) {
+	homeDir, err = ioutil.TempDir("", "gpgme") // created with mode 0700
+	if err != nil {
+		return "", nil, err
+	}
+	info := c.EngineInfo()
+	var prev, temp []EngineConfig
+	for _, proto := range []Protocol{ProtocolOpenPGP, ProtocolCMS} {
+		var fileName, prevHomeDir string
+		if info := info.ForProtocol(proto); info != nil {
+			fileName, prevHomeDir = info.FileName(), info.HomeDir()
+		}
+		prev = append(prev, EngineConfig{Protocol: proto, FileName: fileName, HomeDir: prevHomeDir})
+		temp = append(temp, EngineConfig{Protocol: proto, FileName: fileName, HomeDir: homeDir})
+	}
+	if err := c.SetEngineInfos(temp...); err != nil {
+		_ = c.SetEngineInfos(prev...)
+		os.RemoveAll(homeDir)
+		return "", nil, err
+	}
+	cleanup = func() error {
+		var err error
+		if c.ctx != nil { // a released context would change the defaults instead
+			err = c.SetEngineInfos(prev...)
+		}
+		killAgent(homeDir)
+		if rmErr := os.RemoveAll(homeDir); err == nil {
+			err = rmErr
+		}
+		return err
+	}
+	return homeDir, cleanup, nil
+}
+
+// EphemeralContext is a context using a temporary home directory, see
+// NewEphemeralContext
+type EphemeralContext struct {
+	*Context
+	homeDir string
+	cleanup func() error
+}
+
+// NewEphemeralContext returns a new context using a temporary home directory
+// as WithTemporaryHome. Close releases the context and removes the directory.
+func NewEphemeralContext() (*EphemeralContext, error) {
+	ctx, err := New()
+	if err != nil {
+		return nil, err
+	}
+	homeDir, cleanup, err := ctx.WithTemporaryHome()
+	if err != nil {
+		ctx.Release()
+		return nil, err
+	}
+	return &EphemeralContext{Context: ctx, homeDir: homeDir, cleanup: cleanup}, nil
+}
+
+// HomeDir returns the temporary home directory of c.
+func (c *EphemeralContext) HomeDir() string {
+	return c.homeDir
+}
+
+// Close stops the agent of the temporary home directory, removes it and
+// releases the context. Calling Close more than once has no effect.
+func (c *EphemeralContext) Close() error {
+	if c.cleanup == nil {
+		return nil
+	}
+	err := c.cleanup()
+	c.cleanup = nil
+	c.Release()
+	return err
+}
+
+// killAgent stops the agent and other daemons started for homeDir, if any.
+func killAgent(homeDir string) {
+	gpgconf := GetDirInfo("gpgconf-name")
+	if gpgconf == "" {
+		return
+	}
+	_ = exec.Command(gpgconf, "--homedir", homeDir, "--kill", "all").Run()
+}
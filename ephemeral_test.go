@@ -0,0 +1,50 @@
+package gpgme
+
+import (
+	"os"
+	"testing"
+)
+
+func TestNewEphemeralContext(t *testing.T) {
+	ctx, err := NewEphemeralContext()
+	checkError(t, err)
+
+	homeDir := ctx.HomeDir()
+	fi, err := os.Stat(homeDir)
+	checkError(t, err)
+	if perm := fi.Mode().Perm(); perm != 0700 {
+		t.Errorf("Home directory mode = %o, want 700", perm)
+	}
+	for _, proto := range []Protocol{ProtocolOpenPGP, ProtocolCMS} {
+		info := ctx.EngineInfo().ForProtocol(proto)
+		if info == nil || info.HomeDir() != homeDir {
+			t.Errorf("Expected %s engine to use %s", proto, homeDir)
+		}
+	}
+
+	checkError(t, ctx.Close())
+	if _, err := os.Stat(homeDir); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be removed, got %v", homeDir, err)
+	}
+	checkError(t, ctx.Close())
+}
+
+func TestContext_WithTemporaryHome(t *testing.T) {
+	ctx, err := New()
+	checkError(t, err)
+	checkError(t, ctx.SetEngineInfo(ProtocolOpenPGP, "", "testHomeDir"))
+
+	homeDir, cleanup, err := ctx.WithTemporaryHome()
+	checkError(t, err)
+	if info := ctx.EngineInfo().ForProtocol(ProtocolOpenPGP); info.HomeDir() != homeDir {
+		t.Errorf("HomeDir() = %q, want %q", info.HomeDir(), homeDir)
+	}
+
+	checkError(t, cleanup())
+	if info := ctx.EngineInfo().ForProtocol(ProtocolOpenPGP); info.HomeDir() != "testHomeDir" {
+		t.Errorf("Expected previous home directory to be restored, got %q", info.HomeDir())
+	}
+	if _, err := os.Stat(homeDir); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be removed, got %v", homeDir, err)
+	}
+}
@@ -0,0 +1,59 @@
+package gpgme
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strings"
+)
+
+// Fingerprint is a normalized OpenPGP fingerprint: upper case hex without
+// spaces, 40 digits for v4 keys and 64 digits for v5 and v6 keys.
+type Fingerprint string
+
+// ParseFingerprint normalizes s into a Fingerprint. Spaces, an optional 0x
+// prefix and the case of the hex digits are ignored, so the output of
+// gpg --fingerprint is accepted as is.
+func ParseFingerprint(s string) (Fingerprint, error) {
+	norm := strings.ToUpper(strings.Join(strings.Fields(s), ""))
+	norm = strings.TrimPrefix(norm, "0X")
+	if len(norm) != 40 && len(norm) != 64 {
+		return "", fmt.Errorf("invalid fingerprint %q: want 40 or 64 hex digits, got %d", s, len(norm))
+	}
+	for _, r := range norm {
+		if (r < '0' || r > '9') && (r < 'A' || r > 'F') {
+			return "", fmt.Errorf("invalid fingerprint %q: not hex", s)
+		}
+	}
+	return Fingerprint(norm), nil
+}
+
+func (f Fingerprint) String() string {
+	return string(f)
+}
+
+// KeyID returns the 16 digit long key ID: the low 64 bits of a v4
+// fingerprint, the high 64 bits of a v5 or v6 fingerprint.
+func (f Fingerprint) KeyID() string {
+	switch len(f) {
+	case 40:
+		return string(f[24:])
+	case 64:
+		return string(f[:16])
+	}
+	return ""
+}
+
+// ShortKeyID returns the 8 digit short key ID. Short key IDs are easily
+// forged and should only be used for display.
+func (f Fingerprint) ShortKeyID() string {
+	id := f.KeyID()
+	if id == "" {
+		return ""
+	}
+	return id[8:]
+}
+
+// Equal compares two fingerprints in constant time
+func (f Fingerprint) Equal(other Fingerprint) bool {
+	return subtle.ConstantTimeCompare([]byte(f), []byte(other)) == 1
+}
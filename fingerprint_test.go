@@ -0,0 +1,31 @@
+package gpgme
+
+import "testing"
+
+func TestParseFingerprint(t *testing.T) {
+	const want = Fingerprint("44B646DC347C31E867FF4F450327FFB0229F6136")
+	for _, s := range []string{
+		"44B646DC347C31E867FF4F450327FFB0229F6136",
+		"44b646dc347c31e867ff4f450327ffb0229f6136",
+		"44B6 46DC 347C 31E8 67FF  4F45 0327 FFB0 229F 6136",
+		"0x44B646DC347C31E867FF4F450327FFB0229F6136",
+	} {
+		fpr, err := ParseFingerprint(s)
+		checkError(t, err)
+		if !fpr.Equal(want) {
+			t.Errorf("ParseFingerprint(%q) = %s, want %s", s, fpr, want)
+		}
+	}
+	if id := want.KeyID(); id != "0327FFB0229F6136" {
+		t.Errorf("KeyID() = %s", id)
+	}
+	if id := want.ShortKeyID(); id != "229F6136" {
+		t.Errorf("ShortKeyID() = %s", id)
+	}
+
+	for _, s := range []string{"", "229F6136", "44B646DC347C31E867FF4F450327FFB0229F613G"} {
+		if _, err := ParseFingerprint(s); err == nil {
+			t.Errorf("ParseFingerprint(%q) expected error", s)
+		}
+	}
+}
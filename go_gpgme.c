@@ -1,9 +1,47 @@
+#include <locale.h>
+#include <string.h>
+
 #include "go_gpgme.h"
 
 gpgme_off_t gogpgme_data_seek(gpgme_data_t dh, gpgme_off_t offset, int whence) {
 	return gpgme_data_seek(dh, offset, whence);
 }
 
+// gogpgme_set_locale sets the LC_CTYPE and LC_MESSAGES locales of ctx, or the
+// default for new contexts if ctx is NULL, skipping NULL values. LC_MESSAGES
+// is not available on all platforms.
+gpgme_error_t gogpgme_set_locale(gpgme_ctx_t ctx, const char *ctype, const char *messages) {
+	gpgme_error_t err = 0;
+	if (ctype)
+		err = gpgme_set_locale(ctx, LC_CTYPE, ctype);
+#ifdef LC_MESSAGES
+	if (!err && messages)
+		err = gpgme_set_locale(ctx, LC_MESSAGES, messages);
+#endif
+	return err;
+}
+
+// gogpgme_conf_string returns the value of the string option name of the
+// component comp in conf, its default if it is not set, or NULL.
+const char *gogpgme_conf_string(gpgme_conf_comp_t conf, const char *comp, const char *name) {
+	for (; conf; conf = conf->next) {
+		if (!conf->name || strcmp(conf->name, comp))
+			continue;
+		for (gpgme_conf_opt_t opt = conf->options; opt; opt = opt->next) {
+			if (!opt->name || strcmp(opt->name, name))
+				continue;
+			if (opt->alt_type != GPGME_CONF_STRING)
+				return NULL;
+			if (opt->value && !opt->value->no_arg)
+				return opt->value->value.string;
+			if (opt->default_value && !opt->default_value->no_arg)
+				return opt->default_value->value.string;
+			return NULL;
+		}
+	}
+	return NULL;
+}
+
 gpgme_error_t gogpgme_op_assuan_transact_ext(
 		gpgme_ctx_t ctx,
 		char* cmd,
@@ -62,6 +100,10 @@ unsigned int key_is_qualified(gpgme_key_t k) {
 	return k->is_qualified;
 }
 
+unsigned int key_origin(gpgme_key_t k) {
+	return k->origin;
+}
+
 unsigned int signature_wrong_key_usage(gpgme_signature_t s) {
     return s->wrong_key_usage;
 }
@@ -74,6 +116,10 @@ unsigned int signature_chain_model(gpgme_signature_t s) {
     return s->chain_model;
 }
 
+unsigned int signature_is_de_vs(gpgme_signature_t s) {
+    return s->is_de_vs;
+}
+
 unsigned int subkey_revoked(gpgme_subkey_t k) {
 	return k->revoked;
 }
@@ -94,6 +140,14 @@ unsigned int subkey_secret(gpgme_subkey_t k) {
 	return k->secret;
 }
 
+unsigned int subkey_is_cardkey(gpgme_subkey_t k) {
+	return k->is_cardkey;
+}
+
+unsigned int subkey_is_de_vs(gpgme_subkey_t k) {
+	return k->is_de_vs;
+}
+
 unsigned int uid_revoked(gpgme_user_id_t u) {
 	return u->revoked;
 }
@@ -101,3 +155,87 @@ unsigned int uid_revoked(gpgme_user_id_t u) {
 unsigned int uid_invalid(gpgme_user_id_t u) {
 	return u->invalid;
 }
+
+unsigned int uid_origin(gpgme_user_id_t u) {
+	return u->origin;
+}
+
+unsigned int tofu_validity(gpgme_tofu_info_t t) {
+	return t->validity;
+}
+
+unsigned int tofu_policy(gpgme_tofu_info_t t) {
+	return t->policy;
+}
+
+unsigned int key_sig_revoked(gpgme_key_sig_t s) {
+	return s->revoked;
+}
+
+unsigned int key_sig_expired(gpgme_key_sig_t s) {
+	return s->expired;
+}
+
+unsigned int key_sig_invalid(gpgme_key_sig_t s) {
+	return s->invalid;
+}
+
+unsigned int key_sig_exportable(gpgme_key_sig_t s) {
+	return s->exportable;
+}
+
+unsigned int genkey_result_primary(gpgme_genkey_result_t r) {
+	return r->primary;
+}
+
+unsigned int genkey_result_sub(gpgme_genkey_result_t r) {
+	return r->sub;
+}
+
+unsigned int genkey_result_uid(gpgme_genkey_result_t r) {
+	return r->uid;
+}
+
+unsigned int decrypt_result_is_mime(gpgme_decrypt_result_t r) {
+	return r->is_mime;
+}
+
+unsigned int decrypt_result_wrong_key_usage(gpgme_decrypt_result_t r) {
+	return r->wrong_key_usage;
+}
+
+unsigned int decrypt_result_legacy_cipher_nomdc(gpgme_decrypt_result_t r) {
+	return r->legacy_cipher_nomdc;
+}
+
+unsigned int decrypt_result_is_de_vs(gpgme_decrypt_result_t r) {
+	return r->is_de_vs;
+}
+
+unsigned int swdb_result_warning(gpgme_query_swdb_result_t r) {
+	return r->warning;
+}
+
+unsigned int swdb_result_update(gpgme_query_swdb_result_t r) {
+	return r->update;
+}
+
+unsigned int swdb_result_urgent(gpgme_query_swdb_result_t r) {
+	return r->urgent;
+}
+
+unsigned int swdb_result_noinfo(gpgme_query_swdb_result_t r) {
+	return r->noinfo;
+}
+
+unsigned int swdb_result_unknown(gpgme_query_swdb_result_t r) {
+	return r->unknown;
+}
+
+unsigned int swdb_result_tooold(gpgme_query_swdb_result_t r) {
+	return r->tooold;
+}
+
+unsigned int swdb_result_error(gpgme_query_swdb_result_t r) {
+	return r->error;
+}
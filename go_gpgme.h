@@ -11,6 +11,8 @@ extern ssize_t gogpgme_writefunc(void *handle, void *buffer, size_t size);
 extern off_t gogpgme_seekfunc(void *handle, off_t offset, int whence);
 extern gpgme_error_t gogpgme_passfunc(void *hook, char *uid_hint, char *passphrase_info, int prev_was_bad, int fd);
 extern gpgme_off_t gogpgme_data_seek(gpgme_data_t dh, gpgme_off_t offset, int whence);
+extern gpgme_error_t gogpgme_set_locale(gpgme_ctx_t ctx, const char *ctype, const char *messages);
+extern const char *gogpgme_conf_string(gpgme_conf_comp_t conf, const char *comp, const char *name);
 
 extern gpgme_error_t gogpgme_op_assuan_transact_ext(gpgme_ctx_t ctx, char *cmd, void *data_h, void *inquiry_h , void *status_h, gpgme_error_t *operr);
 
@@ -18,6 +20,9 @@ extern gpgme_error_t gogpgme_assuan_data_callback(void *opaque, void* data, size
 extern gpgme_error_t gogpgme_assuan_inquiry_callback(void *opaque, char* name, char* args);
 extern gpgme_error_t gogpgme_assuan_status_callback(void *opaque, char* status, char* args);
 
+extern gpgme_error_t gogpgme_interactfunc(void *opaque, char *keyword, char *args, int fd);
+extern gpgme_error_t gogpgme_op_interact(gpgme_ctx_t ctx, gpgme_key_t key, unsigned int flags, void *handle, gpgme_data_t out);
+
 extern unsigned int key_revoked(gpgme_key_t k);
 extern unsigned int key_expired(gpgme_key_t k);
 extern unsigned int key_disabled(gpgme_key_t k);
@@ -28,15 +33,40 @@ extern unsigned int key_can_certify(gpgme_key_t k);
 extern unsigned int key_secret(gpgme_key_t k);
 extern unsigned int key_can_authenticate(gpgme_key_t k);
 extern unsigned int key_is_qualified(gpgme_key_t k);
+extern unsigned int key_origin(gpgme_key_t k);
 extern unsigned int signature_wrong_key_usage(gpgme_signature_t s);
 extern unsigned int signature_pka_trust(gpgme_signature_t s);
 extern unsigned int signature_chain_model(gpgme_signature_t s);
+extern unsigned int signature_is_de_vs(gpgme_signature_t s);
 extern unsigned int subkey_revoked(gpgme_subkey_t k);
 extern unsigned int subkey_expired(gpgme_subkey_t k);
 extern unsigned int subkey_disabled(gpgme_subkey_t k);
 extern unsigned int subkey_invalid(gpgme_subkey_t k);
 extern unsigned int subkey_secret(gpgme_subkey_t k);
+extern unsigned int subkey_is_cardkey(gpgme_subkey_t k);
+extern unsigned int subkey_is_de_vs(gpgme_subkey_t k);
 extern unsigned int uid_revoked(gpgme_user_id_t u);
 extern unsigned int uid_invalid(gpgme_user_id_t u);
+extern unsigned int uid_origin(gpgme_user_id_t u);
+extern unsigned int tofu_validity(gpgme_tofu_info_t t);
+extern unsigned int tofu_policy(gpgme_tofu_info_t t);
+extern unsigned int key_sig_revoked(gpgme_key_sig_t s);
+extern unsigned int key_sig_expired(gpgme_key_sig_t s);
+extern unsigned int key_sig_invalid(gpgme_key_sig_t s);
+extern unsigned int key_sig_exportable(gpgme_key_sig_t s);
+extern unsigned int genkey_result_primary(gpgme_genkey_result_t r);
+extern unsigned int genkey_result_sub(gpgme_genkey_result_t r);
+extern unsigned int genkey_result_uid(gpgme_genkey_result_t r);
+extern unsigned int decrypt_result_is_mime(gpgme_decrypt_result_t r);
+extern unsigned int decrypt_result_wrong_key_usage(gpgme_decrypt_result_t r);
+extern unsigned int decrypt_result_legacy_cipher_nomdc(gpgme_decrypt_result_t r);
+extern unsigned int decrypt_result_is_de_vs(gpgme_decrypt_result_t r);
+extern unsigned int swdb_result_warning(gpgme_query_swdb_result_t r);
+extern unsigned int swdb_result_update(gpgme_query_swdb_result_t r);
+extern unsigned int swdb_result_urgent(gpgme_query_swdb_result_t r);
+extern unsigned int swdb_result_noinfo(gpgme_query_swdb_result_t r);
+extern unsigned int swdb_result_unknown(gpgme_query_swdb_result_t r);
+extern unsigned int swdb_result_tooold(gpgme_query_swdb_result_t r);
+extern unsigned int swdb_result_error(gpgme_query_swdb_result_t r);
 
 #endif
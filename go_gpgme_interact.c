@@ -0,0 +1,5 @@
+#include "go_gpgme.h"
+
+gpgme_error_t gogpgme_op_interact(gpgme_ctx_t ctx, gpgme_key_t key, unsigned int flags, void *handle, gpgme_data_t out) {
+	return gpgme_op_interact(ctx, key, flags, (gpgme_interact_cb_t) gogpgme_interactfunc, handle, out);
+}
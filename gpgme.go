@@ -9,11 +9,14 @@ package gpgme
 import "C"
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"runtime"
 	"runtime/cgo"
+	"strings"
 	"time"
 	"unsafe"
 )
@@ -54,6 +57,13 @@ const (
 	ProtocolUnknown  Protocol = C.GPGME_PROTOCOL_UNKNOWN
 )
 
+func (p Protocol) String() string {
+	if name := C.gpgme_get_protocol_name(C.gpgme_protocol_t(p)); name != nil {
+		return C.GoString(name)
+	}
+	return fmt.Sprintf("Protocol(%d)", int(p))
+}
+
 type PinEntryMode int
 
 const (
@@ -68,14 +78,77 @@ type EncryptFlag uint
 
 const (
 	EncryptAlwaysTrust EncryptFlag = C.GPGME_ENCRYPT_ALWAYS_TRUST
+	// EncryptNoEncryptTo ignores the encrypt-to keys of the engine
+	// configuration.
 	EncryptNoEncryptTo EncryptFlag = C.GPGME_ENCRYPT_NO_ENCRYPT_TO
 	EncryptPrepare     EncryptFlag = C.GPGME_ENCRYPT_PREPARE
-	EncryptExceptSign  EncryptFlag = C.GPGME_ENCRYPT_EXPECT_SIGN
+	// EncryptExpectSign is used with EncryptPrepare by UI servers to announce
+	// that the message will also be signed.
+	EncryptExpectSign EncryptFlag = C.GPGME_ENCRYPT_EXPECT_SIGN
+	// EncryptNoCompress disables compression of the plaintext.
+	EncryptNoCompress EncryptFlag = C.GPGME_ENCRYPT_NO_COMPRESS
+	// EncryptSymmetric encrypts with a passphrase, obtained through the
+	// passphrase callback or pinentry, instead of or in addition to the
+	// recipients.
+	EncryptSymmetric EncryptFlag = C.GPGME_ENCRYPT_SYMMETRIC
+	// EncryptThrowKeyIDs omits the recipient key IDs from the ciphertext,
+	// like gpg --throw-keyids, so the recipients cannot be identified.
+	EncryptThrowKeyIDs EncryptFlag = C.GPGME_ENCRYPT_THROW_KEYIDS
+	// EncryptWantAddress requires recipients given as strings to be mail
+	// addresses, see EncryptExt.
+	EncryptWantAddress EncryptFlag = C.GPGME_ENCRYPT_WANT_ADDRESS
+
+	// EncryptWrap encrypts plaintext that is already an OpenPGP message, as
+	// produced by DecryptUnwrap, without adding another literal data layer.
+	EncryptWrap EncryptFlag = C.GPGME_ENCRYPT_WRAP
+
+	// Deprecated: Use EncryptExpectSign.
+	EncryptExceptSign = EncryptExpectSign
+)
+
+type DecryptFlag uint
+
+const (
+	// DecryptVerify also verifies signatures of the plaintext, see DecryptExt.
+	DecryptVerify DecryptFlag = C.GPGME_DECRYPT_VERIFY
+	// DecryptUnwrap removes the encryption layer and outputs the inner
+	// OpenPGP message, with any signatures, instead of the plaintext. It can
+	// be re-encrypted to other recipients with EncryptWrap.
+	DecryptUnwrap DecryptFlag = C.GPGME_DECRYPT_UNWRAP
 )
 
 type HashAlgo int
 
-// const values for HashAlgo values should be added when necessary.
+const (
+	HashNone         HashAlgo = C.GPGME_MD_NONE
+	HashMD5          HashAlgo = C.GPGME_MD_MD5
+	HashSHA1         HashAlgo = C.GPGME_MD_SHA1
+	HashRMD160       HashAlgo = C.GPGME_MD_RMD160
+	HashMD2          HashAlgo = C.GPGME_MD_MD2
+	HashTiger        HashAlgo = C.GPGME_MD_TIGER
+	HashHaval        HashAlgo = C.GPGME_MD_HAVAL
+	HashSHA256       HashAlgo = C.GPGME_MD_SHA256
+	HashSHA384       HashAlgo = C.GPGME_MD_SHA384
+	HashSHA512       HashAlgo = C.GPGME_MD_SHA512
+	HashSHA224       HashAlgo = C.GPGME_MD_SHA224
+	HashMD4          HashAlgo = C.GPGME_MD_MD4
+	HashCRC32        HashAlgo = C.GPGME_MD_CRC32
+	HashCRC32RFC1510 HashAlgo = C.GPGME_MD_CRC32_RFC1510
+	HashCRC24RFC2440 HashAlgo = C.GPGME_MD_CRC24_RFC2440
+)
+
+// Name returns the name of the algorithm as used by GnuPG, e.g. "SHA256",
+// or an empty string if it is unknown
+func (h HashAlgo) Name() string {
+	return C.GoString(C.gpgme_hash_algo_name(C.gpgme_hash_algo_t(h)))
+}
+
+func (h HashAlgo) String() string {
+	if name := h.Name(); name != "" {
+		return name
+	}
+	return fmt.Sprintf("HashAlgo(%d)", int(h))
+}
 
 type KeyListMode uint
 
@@ -84,13 +157,47 @@ const (
 	KeyListModeExtern       KeyListMode = C.GPGME_KEYLIST_MODE_EXTERN
 	KeyListModeSigs         KeyListMode = C.GPGME_KEYLIST_MODE_SIGS
 	KeyListModeSigNotations KeyListMode = C.GPGME_KEYLIST_MODE_SIG_NOTATIONS
+	KeyListModeWithSecret   KeyListMode = C.GPGME_KEYLIST_MODE_WITH_SECRET
+	KeyListModeWithTofu     KeyListMode = C.GPGME_KEYLIST_MODE_WITH_TOFU
+	KeyListModeWithKeygrip  KeyListMode = C.GPGME_KEYLIST_MODE_WITH_KEYGRIP
 	KeyListModeEphemeral    KeyListMode = C.GPGME_KEYLIST_MODE_EPHEMERAL
-	KeyListModeModeValidate KeyListMode = C.GPGME_KEYLIST_MODE_VALIDATE
+	KeyListModeValidate     KeyListMode = C.GPGME_KEYLIST_MODE_VALIDATE
+	// KeyListModeLocate looks up keys like KeyListModeLocal|KeyListModeExtern
+	// but follows the auto-key-locate configuration of the engine.
+	KeyListModeLocate KeyListMode = C.GPGME_KEYLIST_MODE_LOCATE
+
+	// Deprecated: Use KeyListModeValidate.
+	KeyListModeModeValidate = KeyListModeValidate
 )
 
 type PubkeyAlgo int
 
-// const values for PubkeyAlgo values should be added when necessary.
+const (
+	PubkeyRSA   PubkeyAlgo = C.GPGME_PK_RSA
+	PubkeyRSAE  PubkeyAlgo = C.GPGME_PK_RSA_E
+	PubkeyRSAS  PubkeyAlgo = C.GPGME_PK_RSA_S
+	PubkeyElgE  PubkeyAlgo = C.GPGME_PK_ELG_E
+	PubkeyDSA   PubkeyAlgo = C.GPGME_PK_DSA
+	PubkeyECC   PubkeyAlgo = C.GPGME_PK_ECC
+	PubkeyElg   PubkeyAlgo = C.GPGME_PK_ELG
+	PubkeyECDSA PubkeyAlgo = C.GPGME_PK_ECDSA
+	PubkeyECDH  PubkeyAlgo = C.GPGME_PK_ECDH
+	PubkeyEdDSA PubkeyAlgo = C.GPGME_PK_EDDSA
+)
+
+// Name returns the name of the algorithm as used by GnuPG, e.g. "RSA", or an
+// empty string if it is unknown. See SubKey.AlgoString for a name including
+// the key size or curve.
+func (a PubkeyAlgo) Name() string {
+	return C.GoString(C.gpgme_pubkey_algo_name(C.gpgme_pubkey_algo_t(a)))
+}
+
+func (a PubkeyAlgo) String() string {
+	if name := a.Name(); name != "" {
+		return name
+	}
+	return fmt.Sprintf("PubkeyAlgo(%d)", int(a))
+}
 
 type SigMode int
 
@@ -100,6 +207,18 @@ const (
 	SigModeClear  SigMode = C.GPGME_SIG_MODE_CLEAR
 )
 
+func (m SigMode) String() string {
+	switch m {
+	case SigModeNormal:
+		return "normal"
+	case SigModeDetach:
+		return "detach"
+	case SigModeClear:
+		return "clear"
+	}
+	return fmt.Sprintf("SigMode(%d)", int(m))
+}
+
 type SigSum int
 
 const (
@@ -127,11 +246,82 @@ const (
 	ValidityUltimate  Validity = C.GPGME_VALIDITY_ULTIMATE
 )
 
+func (v Validity) String() string {
+	switch v {
+	case ValidityUnknown:
+		return "unknown"
+	case ValidityUndefined:
+		return "undefined"
+	case ValidityNever:
+		return "never"
+	case ValidityMarginal:
+		return "marginal"
+	case ValidityFull:
+		return "full"
+	case ValidityUltimate:
+		return "ultimate"
+	}
+	return fmt.Sprintf("Validity(%d)", int(v))
+}
+
+// AtLeast reports whether v is at least as trusted as min. ValidityNever
+// ranks below ValidityUnknown and ValidityUndefined, which rank the same.
+func (v Validity) AtLeast(min Validity) bool {
+	return v.rank() >= min.rank()
+}
+
+func (v Validity) rank() int {
+	switch v {
+	case ValidityNever:
+		return 0
+	case ValidityUnknown, ValidityUndefined:
+		return 1
+	case ValidityMarginal:
+		return 2
+	case ValidityFull:
+		return 3
+	case ValidityUltimate:
+		return 4
+	}
+	return -1
+}
+
+// TofuPolicy is the TOFU policy of a key
+type TofuPolicy int
+
+const (
+	TofuPolicyNone    TofuPolicy = C.GPGME_TOFU_POLICY_NONE
+	TofuPolicyAuto    TofuPolicy = C.GPGME_TOFU_POLICY_AUTO
+	TofuPolicyGood    TofuPolicy = C.GPGME_TOFU_POLICY_GOOD
+	TofuPolicyUnknown TofuPolicy = C.GPGME_TOFU_POLICY_UNKNOWN
+	TofuPolicyBad     TofuPolicy = C.GPGME_TOFU_POLICY_BAD
+	TofuPolicyAsk     TofuPolicy = C.GPGME_TOFU_POLICY_ASK
+)
+
+// KeyOrigin describes where a key or user ID was obtained from
+type KeyOrigin int
+
+const (
+	KeyOriginUnknown   KeyOrigin = C.GPGME_KEYORG_UNKNOWN
+	KeyOriginKeyServer KeyOrigin = C.GPGME_KEYORG_KS
+	KeyOriginDANE      KeyOrigin = C.GPGME_KEYORG_DANE
+	KeyOriginWKD       KeyOrigin = C.GPGME_KEYORG_WKD
+	KeyOriginURL       KeyOrigin = C.GPGME_KEYORG_URL
+	KeyOriginFile      KeyOrigin = C.GPGME_KEYORG_FILE
+	KeyOriginSelf      KeyOrigin = C.GPGME_KEYORG_SELF
+	KeyOriginOther     KeyOrigin = C.GPGME_KEYORG_OTHER
+)
+
 type ErrorCode int
 
 const (
-	ErrorNoError ErrorCode = C.GPG_ERR_NO_ERROR
-	ErrorEOF     ErrorCode = C.GPG_ERR_EOF
+	ErrorNoError      ErrorCode = C.GPG_ERR_NO_ERROR
+	ErrorEOF          ErrorCode = C.GPG_ERR_EOF
+	ErrorBadSignature ErrorCode = C.GPG_ERR_BAD_SIGNATURE
+	ErrorNoPubkey     ErrorCode = C.GPG_ERR_NO_PUBKEY
+	ErrorCertRevoked  ErrorCode = C.GPG_ERR_CERT_REVOKED
+	ErrorKeyExpired   ErrorCode = C.GPG_ERR_KEY_EXPIRED
+	ErrorSigExpired   ErrorCode = C.GPG_ERR_SIG_EXPIRED
 )
 
 // Error is a wrapper for GPGME errors
@@ -155,6 +345,15 @@ func handleError(err C.gpgme_error_t) error {
 	return e
 }
 
+// unixTime converts a timestamp reported by GPGME, where 0 means unknown or
+// unset, to a time.Time.
+func unixTime(secs int64) time.Time {
+	if secs <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(secs, 0)
+}
+
 func cbool(b bool) C.int {
 	if b {
 		return 1
@@ -162,10 +361,48 @@ func cbool(b bool) C.int {
 	return 0
 }
 
+// cstrings returns a NULL terminated array of C strings, which must be
+// released with freeCStrings.
+func cstrings(strs []string) **C.char {
+	arr := (**C.char)(C.calloc(C.size_t(len(strs)+1), C.size_t(unsafe.Sizeof((*C.char)(nil)))))
+	ptrs := unsafe.Slice(arr, len(strs)+1)
+	for i, s := range strs {
+		ptrs[i] = C.CString(s)
+	}
+	return arr
+}
+
+func freeCStrings(arr **C.char) {
+	for p := arr; *p != nil; p = (**C.char)(unsafe.Add(unsafe.Pointer(p), unsafe.Sizeof(*p))) {
+		C.free(unsafe.Pointer(*p))
+	}
+	C.free(unsafe.Pointer(arr))
+}
+
+// SetGlobalFlag sets the global GPGME flag name to value, e.g.
+// "require-gnupg" to demand a minimum GnuPG version or "w32-inst-dir" to use
+// a bundled installation on Windows. Global flags are only honored if set
+// before GPGME first needs them, so SetGlobalFlag must be called before the
+// first context is created. Flags affecting the initialization of GPGME
+// itself, such as "debug", have no effect since the package initializes
+// GPGME when it is loaded.
+func SetGlobalFlag(name, value string) error {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	cvalue := C.CString(value)
+	defer C.free(unsafe.Pointer(cvalue))
+	if C.gpgme_set_global_flag(cname, cvalue) != 0 {
+		return fmt.Errorf("setting global flag %q failed", name)
+	}
+	return nil
+}
+
 func EngineCheckVersion(p Protocol) error {
 	return handleError(C.gpgme_engine_check_version(C.gpgme_protocol_t(p)))
 }
 
+// EngineInfo describes the engine of a protocol. The engines of all
+// protocols form a list, see Next and ForProtocol.
 type EngineInfo struct {
 	next            *EngineInfo
 	protocol        Protocol
@@ -194,26 +431,44 @@ func (e *EngineInfo) Next() *EngineInfo {
 	return e.next
 }
 
+// ForProtocol returns the engine info of proto from the list starting at e,
+// or nil if there is none.
+func (e *EngineInfo) ForProtocol(proto Protocol) *EngineInfo {
+	for ; e != nil; e = e.next {
+		if e.protocol == proto {
+			return e
+		}
+	}
+	return nil
+}
+
 func (e *EngineInfo) Protocol() Protocol {
 	return e.protocol
 }
 
+// FileName returns the path of the engine executable.
 func (e *EngineInfo) FileName() string {
 	return e.fileName
 }
 
+// Version returns the version of the installed engine, or empty if it is
+// not available.
 func (e *EngineInfo) Version() string {
 	return e.version
 }
 
+// RequiredVersion returns the minimum engine version required by GPGME.
 func (e *EngineInfo) RequiredVersion() string {
 	return e.requiredVersion
 }
 
+// HomeDir returns the home directory of the engine, or empty for the
+// default.
 func (e *EngineInfo) HomeDir() string {
 	return e.homeDir
 }
 
+// GetEngineInfo returns the default engine info of all protocols.
 func GetEngineInfo() (*EngineInfo, error) {
 	var cInfo C.gpgme_engine_info_t
 	err := handleError(C.gpgme_get_engine_info(&cInfo))
@@ -223,6 +478,10 @@ func GetEngineInfo() (*EngineInfo, error) {
 	return copyEngineInfo(cInfo), nil // It is up to the caller not to invalidate cInfo concurrently until this is done.
 }
 
+// SetEngineInfo sets the default engine executable and home directory of
+// proto for all contexts created afterwards, e.g. to use a bundled gpg.
+// Existing contexts are not changed, see Context.SetEngineInfo. An empty
+// fileName or homeDir selects the default of GPGME.
 func SetEngineInfo(proto Protocol, fileName, homeDir string) error {
 	var cfn, chome *C.char
 	if fileName != "" {
@@ -267,6 +526,264 @@ func FindKeys(pattern string, secretOnly bool) ([]*Key, error) {
 	return keys, nil
 }
 
+// EncryptWithPassphrase encrypts the data read from r with passphrase only,
+// as the EncryptSymmetric flag without recipients, writing the ciphertext to
+// w. The passphrase is passed to the engine with
+// loopback pinentry, which requires allow-loopback-pinentry with GnuPG 2.1.
+func EncryptWithPassphrase(r io.Reader, w io.Writer, passphrase string) error {
+	ctx, err := New()
+	if err != nil {
+		return err
+	}
+	defer ctx.Release()
+	if err := ctx.SetPinEntryMode(PinEntryLoopback); err != nil {
+		return err
+	}
+	if err := ctx.SetCallback(func(uidHint string, prevWasBad bool, f *os.File) error {
+		if prevWasBad {
+			return fmt.Errorf("bad passphrase")
+		}
+		_, err := io.WriteString(f, passphrase+"\n")
+		return err
+	}); err != nil {
+		return err
+	}
+	plain, err := NewDataReader(r)
+	if err != nil {
+		return err
+	}
+	defer plain.Close()
+	cipher, err := NewDataWriter(w)
+	if err != nil {
+		return err
+	}
+	defer cipher.Close()
+	return ctx.Encrypt(nil, EncryptSymmetric, plain, cipher)
+}
+
+// Encrypt encrypts the data read from r for recipients, given as
+// fingerprints, key IDs or mail addresses as for EncryptExt, and returns the
+// ASCII armored ciphertext. Use NewEncryptingWriter to stream it instead.
+func Encrypt(recipients []string, r io.Reader) (*Data, error) {
+	ctx, err := New()
+	if err != nil {
+		return nil, err
+	}
+	defer ctx.Release()
+	ctx.SetArmor(true)
+	plain, err := NewDataReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer plain.Close()
+	cipher, err := NewData()
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.EncryptExt(recipients, 0, plain, cipher); err != nil {
+		cipher.Close()
+		return nil, err
+	}
+	_, err = cipher.Seek(0, SeekSet)
+	return cipher, err
+}
+
+// ErrNoRequiredSignature is returned by DecryptVerifySigned if the message has no
+// valid signature by any of the required signers
+var ErrNoRequiredSignature = errors.New("no valid signature by a required signer")
+
+// DecryptVerifySigned decrypts the message read from r and verifies its
+// signatures.
+// It fails with ErrNoRequiredSignature unless at least one signature is
+// good and was made by one of the signers, given as primary key or signing
+// subkey fingerprints, see hasSignatureBy. The plaintext is only returned if the check passes.
+func DecryptVerifySigned(r io.Reader, signers []Fingerprint) (*Data, []Signature, error) {
+	ctx, err := New()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer ctx.Release()
+	cipher, err := NewDataReader(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cipher.Close()
+	plain, err := NewData()
+	if err != nil {
+		return nil, nil, err
+	}
+	_, sigs, err := ctx.DecryptExt(DecryptVerify, cipher, plain)
+	if err != nil {
+		plain.Close()
+		return nil, nil, err
+	}
+	if !ctx.hasSignatureBy(sigs, signers) {
+		plain.Close()
+		return nil, sigs, ErrNoRequiredSignature
+	}
+	if _, err := plain.Seek(0, SeekSet); err != nil {
+		plain.Close()
+		return nil, nil, err
+	}
+	return plain, sigs, nil
+}
+
+// hasSignatureBy reports whether any of sigs is good and was made by a key
+// with one of the signers fingerprints. Pinning the signer replaces the web
+// of trust, so a signature by a key of unknown validity is accepted, but not
+// one by a key with validity never or a key not meant for signing.
+func (c *Context) hasSignatureBy(sigs []Signature, signers []Fingerprint) bool {
+	for _, sig := range sigs {
+		if sig.Status != nil || sig.Validity == ValidityNever || sig.WrongKeyUsage {
+			continue
+		}
+		if c.signedBy(sig, signers) {
+			return true
+		}
+	}
+	return false
+}
+
+// signedBy reports whether sig was made by one of the signers fingerprints,
+// looking up the key to match signatures made by a subkey against the primary
+// key fingerprint.
+func (c *Context) signedBy(sig Signature, signers []Fingerprint) bool {
+	fprs := []string{sig.Fingerprint}
+	key := sig.Key
+	if key == nil {
+		key, _ = c.GetKey(sig.Fingerprint, false)
+	}
+	if key != nil {
+		fprs = append(fprs, key.SubKeys().Fingerprint())
+	}
+	return matchesSigner(fprs, signers)
+}
+
+// Sign signs the data read from r with the default key of the engine and
+// returns the signed message, or the detached signature for SigModeDetach.
+func Sign(r io.Reader, mode SigMode) (*Data, error) {
+	ctx, err := New()
+	if err != nil {
+		return nil, err
+	}
+	defer ctx.Release()
+	plain, err := NewDataReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer plain.Close()
+	sig, err := NewData()
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Sign(nil, plain, sig, mode); err != nil {
+		sig.Close()
+		return nil, err
+	}
+	if _, err := sig.Seek(0, SeekSet); err != nil {
+		sig.Close()
+		return nil, err
+	}
+	return sig, nil
+}
+
+// Verify verifies the signed or cleartext signed message read from r and
+// returns the signed data and the signatures, which must be checked by the
+// caller.
+func Verify(r io.Reader) (*Data, []Signature, error) {
+	ctx, err := New()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer ctx.Release()
+	signed, err := NewDataReader(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer signed.Close()
+	plain, err := NewData()
+	if err != nil {
+		return nil, nil, err
+	}
+	_, sigs, err := ctx.Verify(signed, nil, plain)
+	if err != nil {
+		plain.Close()
+		return nil, nil, err
+	}
+	if _, err := plain.Seek(0, SeekSet); err != nil {
+		plain.Close()
+		return nil, nil, err
+	}
+	return plain, sigs, nil
+}
+
+// SignDetached creates an ASCII armored detached signature with key of the
+// data read from r. The data is streamed to the engine, so it may be larger
+// than memory.
+func SignDetached(key *Key, r io.Reader) (io.Reader, error) {
+	ctx, err := key.newContext()
+	if err != nil {
+		return nil, err
+	}
+	defer ctx.Release()
+	ctx.SetArmor(true)
+	plain, err := NewDataReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer plain.Close()
+	var buf bytes.Buffer
+	sig, err := NewDataWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+	defer sig.Close()
+	if err := ctx.Sign([]*Key{key}, plain, sig, SigModeDetach); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+// VerifyDetached verifies the detached signature read from sig over the data
+// read from data. The returned signatures must be checked by the caller,
+// e.g. for a Summary with SigSumValid; the error only reports a failure to
+// run the verification.
+func VerifyDetached(sig, data io.Reader) ([]Signature, error) {
+	ctx, err := New()
+	if err != nil {
+		return nil, err
+	}
+	defer ctx.Release()
+	sigData, err := NewDataReader(sig)
+	if err != nil {
+		return nil, err
+	}
+	defer sigData.Close()
+	signedText, err := NewDataReader(data)
+	if err != nil {
+		return nil, err
+	}
+	defer signedText.Close()
+	_, sigs, err := ctx.Verify(sigData, signedText, nil)
+	return sigs, err
+}
+
+// VerifyDetachedFile is like VerifyDetached with the signature and data read
+// from the files sigPath and dataPath.
+func VerifyDetachedFile(sigPath, dataPath string) ([]Signature, error) {
+	sig, err := os.Open(sigPath)
+	if err != nil {
+		return nil, err
+	}
+	defer sig.Close()
+	data, err := os.Open(dataPath)
+	if err != nil {
+		return nil, err
+	}
+	defer data.Close()
+	return VerifyDetached(sig, data)
+}
+
 func Decrypt(r io.Reader) (*Data, error) {
 	ctx, err := New()
 	if err != nil {
@@ -296,6 +813,14 @@ type Context struct {
 	callback Callback
 	cbc      cgo.Handle // WARNING: Call runtime.KeepAlive(c) after ANY use of c.cbc in C (typically via c.ctx)
 
+	keyListData *Data // keeps the data of KeyListFromDataStart alive until KeyListEnd
+
+	maxPlaintext int64 // see SetMaxPlaintextBytes
+
+	pinnedSigners []*Key // see pinSubkey
+
+	engine *keyEngine // cached by keyEngine, reset when the engine changes
+
 	ctx C.gpgme_ctx_t // WARNING: Call runtime.KeepAlive(c) after ANY passing of c.ctx to C
 }
 
@@ -316,6 +841,7 @@ func (c *Context) Release() {
 	C.gpgme_release(c.ctx)
 	runtime.KeepAlive(c)
 	c.ctx = nil
+	c.unpinSubkeys()
 }
 
 func (c *Context) SetArmor(yes bool) {
@@ -329,20 +855,108 @@ func (c *Context) Armor() bool {
 	return res
 }
 
+// SetTextMode sets whether signatures are made in canonical text mode, in
+// which line endings are normalized to CR/LF before signing. This makes
+// detached and cleartext signatures of text documents verify across
+// platforms with different line endings. For OpenPGP encryption it also
+// marks the plaintext as text. See also SignOptions.TextMode.
 func (c *Context) SetTextMode(yes bool) {
 	C.gpgme_set_textmode(c.ctx, cbool(yes))
 	runtime.KeepAlive(c)
 }
 
+// TextMode reports whether canonical text mode is set
 func (c *Context) TextMode() bool {
 	res := C.gpgme_get_textmode(c.ctx) != 0
 	runtime.KeepAlive(c)
 	return res
 }
 
+// SetLocale sets the LC_CTYPE and LC_MESSAGES locales passed to the engine
+// and pinentry, independent of the locale of the process; e.g. "C" for
+// stable, parseable engine messages. An empty value is left unchanged.
+func (c *Context) SetLocale(ctype, messages string) error {
+	var pctype, pmessages *string
+	if ctype != "" {
+		pctype = &ctype
+	}
+	if messages != "" {
+		pmessages = &messages
+	}
+	err := setLocale(c.ctx, pctype, pmessages)
+	runtime.KeepAlive(c)
+	return err
+}
+
+// SetOffline sets whether the engine is kept from accessing the network:
+// CRL and OCSP checks and keyserver lookups are skipped, so verification
+// depends only on local data.
+func (c *Context) SetOffline(yes bool) {
+	C.gpgme_set_offline(c.ctx, cbool(yes))
+	runtime.KeepAlive(c)
+}
+
+// Offline reports whether network access is disabled, see SetOffline
+func (c *Context) Offline() bool {
+	res := C.gpgme_get_offline(c.ctx) != 0
+	runtime.KeepAlive(c)
+	return res
+}
+
+const (
+	// IncludeCertsDefault includes the number of certificates configured
+	// for the engine, see SetIncludeCerts
+	IncludeCertsDefault = C.GPGME_INCLUDE_CERTS_DEFAULT
+	// IncludeCertsAllButRoot includes the whole chain except the root
+	// certificate
+	IncludeCertsAllButRoot = -2
+	// IncludeCertsAll includes the whole chain
+	IncludeCertsAll = -1
+)
+
+// SetIncludeCerts sets how many certificates of the signer's chain are
+// included in CMS signatures: IncludeCertsAll, IncludeCertsAllButRoot, 0 for
+// none, 1 for only the signer's certificate or n for the first n certificates
+// of the chain. It has no effect on OpenPGP.
+func (c *Context) SetIncludeCerts(n int) {
+	C.gpgme_set_include_certs(c.ctx, C.int(n))
+	runtime.KeepAlive(c)
+}
+
+// IncludeCerts returns the number of certificates included in CMS
+// signatures, see SetIncludeCerts
+func (c *Context) IncludeCerts() int {
+	res := int(C.gpgme_get_include_certs(c.ctx))
+	runtime.KeepAlive(c)
+	return res
+}
+
+// SetSender sets the mail address of the sender of messages signed with the
+// context, or clears it if empty. gpg records it in the signatures and uses it
+// for TOFU and Web Key Directory checks; when verifying it is the expected
+// signer address.
+func (c *Context) SetSender(address string) error {
+	var caddr *C.char
+	if address != "" {
+		caddr = C.CString(address)
+		defer C.free(unsafe.Pointer(caddr))
+	}
+	err := handleError(C.gpgme_set_sender(c.ctx, caddr))
+	runtime.KeepAlive(c)
+	return err
+}
+
+// Sender returns the address set with SetSender
+func (c *Context) Sender() string {
+	res := C.GoString(C.gpgme_get_sender(c.ctx))
+	runtime.KeepAlive(c)
+	return res
+}
+
 func (c *Context) SetProtocol(p Protocol) error {
 	err := handleError(C.gpgme_set_protocol(c.ctx, C.gpgme_protocol_t(p)))
 	runtime.KeepAlive(c)
+	c.engine = nil
 	return err
 }
 
@@ -393,6 +1007,157 @@ func (c *Context) SetCallback(callback Callback) error {
 	return err
 }
 
+// SetCtxFlag sets the context flag name to value. It gives access to flags
+// without a dedicated method, e.g. "ignore-mdc-error", "cert-expire" or
+// "full-status"; see the GPGME manual for the flags supported by the
+// installed version.
+func (c *Context) SetCtxFlag(name, value string) error {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	cvalue := C.CString(value)
+	defer C.free(unsafe.Pointer(cvalue))
+	err := handleError(C.gpgme_set_ctx_flag(c.ctx, cname, cvalue))
+	runtime.KeepAlive(c)
+	return err
+}
+
+// setBoolFlag sets the boolean context flag name
+func (c *Context) setBoolFlag(name string, yes bool) error {
+	value := "0"
+	if yes {
+		value = "1"
+	}
+	return c.SetCtxFlag(name, value)
+}
+
+// CtxFlag returns the value of the context flag name, or an empty string if
+// it is unknown or not set
+func (c *Context) CtxFlag(name string) string {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	res := C.GoString(C.gpgme_get_ctx_flag(c.ctx, cname))
+	runtime.KeepAlive(c)
+	return res
+}
+
+// boolFlag reports whether the boolean context flag name is set
+func (c *Context) boolFlag(name string) bool {
+	v := c.CtxFlag(name)
+	return v != "" && v != "0"
+}
+
+// SetAutoKeyRetrieve makes verification fetch unknown signer keys from the
+// Web Key Directory or keyservers and import them, as the auto-key-retrieve
+// option of gpg. Use ResolveSignerKeys to get the fetched keys into
+// Signature.Key.
+func (c *Context) SetAutoKeyRetrieve(yes bool) error {
+	return c.setBoolFlag("auto-key-retrieve", yes)
+}
+
+// AutoKeyRetrieve reports whether SetAutoKeyRetrieve is set
+func (c *Context) AutoKeyRetrieve() bool {
+	return c.boolFlag("auto-key-retrieve")
+}
+
+// SetIncludeKeyBlock makes signing include the signer's public key in the
+// signature, so recipients can import it without a key lookup; see
+// SetAutoKeyImport. It requires GnuPG 2.2.20 or later.
+func (c *Context) SetIncludeKeyBlock(yes bool) error {
+	return c.setBoolFlag("include-key-block", yes)
+}
+
+// IncludeKeyBlock reports whether SetIncludeKeyBlock is set
+func (c *Context) IncludeKeyBlock() bool {
+	return c.boolFlag("include-key-block")
+}
+
+// SetAutoKeyImport makes verification import keys included in signatures
+// with SetIncludeKeyBlock. It requires GnuPG 2.2.20 or later.
+func (c *Context) SetAutoKeyImport(yes bool) error {
+	return c.setBoolFlag("auto-key-import", yes)
+}
+
+// AutoKeyImport reports whether SetAutoKeyImport is set
+func (c *Context) AutoKeyImport() bool {
+	return c.boolFlag("auto-key-import")
+}
+
+// SetNoSymkeyCache keeps gpg-agent from caching passphrases used for
+// symmetric encryption and decryption, e.g. when decrypting untrusted
+// archives. It requires GnuPG 2.2.7 or later.
+func (c *Context) SetNoSymkeyCache(yes bool) error {
+	return c.setBoolFlag("no-symkey-cache", yes)
+}
+
+// NoSymkeyCache reports whether SetNoSymkeyCache is set
+func (c *Context) NoSymkeyCache() bool {
+	return c.boolFlag("no-symkey-cache")
+}
+
+// TrustModel is the model gpg uses to compute the validity of keys
+type TrustModel string
+
+const (
+	TrustModelPGP     TrustModel = "pgp"
+	TrustModelClassic TrustModel = "classic"
+	TrustModelTOFU    TrustModel = "tofu"
+	TrustModelTOFUPGP TrustModel = "tofu+pgp"
+	TrustModelDirect  TrustModel = "direct"
+	TrustModelAlways  TrustModel = "always"
+	TrustModelAuto    TrustModel = "auto"
+)
+
+// SetTrustModel sets the trust model used by the operations of the context
+// instead of the one configured in gpg.conf. It requires GnuPG 2.2 and
+// GPGME 1.14 or later.
+func (c *Context) SetTrustModel(model TrustModel) error {
+	return c.SetCtxFlag("trust-model", string(model))
+}
+
+// TrustModel returns the trust model set with SetTrustModel, or an empty
+// string if none is set
+func (c *Context) TrustModel() TrustModel {
+	return TrustModel(c.CtxFlag("trust-model"))
+}
+
+// RequestOrigin tells gpg-agent where a request comes from, which restricts
+// the operations it allows and changes how it prompts
+type RequestOrigin string
+
+const (
+	RequestOriginNone    RequestOrigin = "none"
+	RequestOriginLocal   RequestOrigin = "local"
+	RequestOriginRemote  RequestOrigin = "remote"
+	RequestOriginBrowser RequestOrigin = "browser"
+)
+
+// SetRequestOrigin sets the origin of the requests of the context, as the
+// request-origin option of gpg
+func (c *Context) SetRequestOrigin(origin RequestOrigin) error {
+	return c.SetCtxFlag("request-origin", string(origin))
+}
+
+// RequestOrigin returns the origin set with SetRequestOrigin, or an empty
+// string if none is set
+func (c *Context) RequestOrigin() RequestOrigin {
+	return RequestOrigin(c.CtxFlag("request-origin"))
+}
+
+// SetExportSessionKey makes decryption report the session key of messages in
+// DecryptResult.SessionKey, so they can later be decrypted with
+// SetOverrideSessionKey without the private key.
+func (c *Context) SetExportSessionKey(yes bool) error {
+	return c.setBoolFlag("export-session-key", yes)
+}
+
+// SetOverrideSessionKey makes decryption use sessionKey, as reported in
+// DecryptResult.SessionKey, instead of the private key. An empty sessionKey
+// returns to normal decryption.
+func (c *Context) SetOverrideSessionKey(sessionKey string) error {
+	return c.SetCtxFlag("override-session-key", sessionKey)
+}
+
+// EngineInfo returns the engine info of all protocols used by c.
 func (c *Context) EngineInfo() *EngineInfo {
 	cInfo := C.gpgme_ctx_get_engine_info(c.ctx)
 	runtime.KeepAlive(c)
@@ -414,9 +1179,32 @@ func (c *Context) SetEngineInfo(proto Protocol, fileName, homeDir string) error
 	}
 	err := handleError(C.gpgme_ctx_set_engine_info(c.ctx, C.gpgme_protocol_t(proto), cfn, chome))
 	runtime.KeepAlive(c)
+	c.engine = nil
 	return err
 }
 
+// EngineConfig configures the engine of a protocol, see
+// Context.SetEngineInfos.
+type EngineConfig struct {
+	Protocol Protocol
+	// FileName and HomeDir are the engine executable and home directory;
+	// empty selects the default.
+	FileName string
+	HomeDir  string
+}
+
+// SetEngineInfos sets the engine info of several protocols of c at once,
+// e.g. a common home directory for OpenPGP and CMS. It stops at the first
+// error.
+func (c *Context) SetEngineInfos(configs ...EngineConfig) error {
+	for _, config := range configs {
+		if err := c.SetEngineInfo(config.Protocol, config.FileName, config.HomeDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *Context) KeyListStart(pattern string, secretOnly bool) error {
 	cpattern := C.CString(pattern)
 	defer C.free(unsafe.Pointer(cpattern))
@@ -425,8 +1213,29 @@ func (c *Context) KeyListStart(pattern string, secretOnly bool) error {
 	return err
 }
 
+// KeyListStartExt starts a key listing for keys matching any of patterns
+func (c *Context) KeyListStartExt(patterns []string, secretOnly bool) error {
+	pats := cstrings(patterns)
+	defer freeCStrings(pats)
+	err := handleError(C.gpgme_op_keylist_ext_start(c.ctx, pats, cbool(secretOnly), 0))
+	runtime.KeepAlive(c)
+	return err
+}
+
+// KeyListFromDataStart starts a key listing of the keys contained in keyData,
+// without importing them into the keyring.
+func (c *Context) KeyListFromDataStart(keyData *Data) error {
+	err := handleError(C.gpgme_op_keylist_from_data_start(c.ctx, keyData.dh, 0))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(keyData)
+	if err == nil {
+		c.keyListData = keyData
+	}
+	return err
+}
+
 func (c *Context) KeyListNext() bool {
-	c.Key = newKey()
+	c.Key = newKey(c)
 	err := handleError(C.gpgme_op_keylist_next(c.ctx, &c.Key.k))
 	runtime.KeepAlive(c) // implies runtime.KeepAlive(c.Key)
 	if err != nil {
@@ -444,11 +1253,55 @@ func (c *Context) KeyListNext() bool {
 func (c *Context) KeyListEnd() error {
 	err := handleError(C.gpgme_op_keylist_end(c.ctx))
 	runtime.KeepAlive(c)
+	c.keyListData = nil
 	return err
 }
 
+// ForEachKey calls fn for each key matching pattern, as KeyListStart. The
+// listing stops at the first error returned by fn, which is returned by
+// ForEachKey.
+func (c *Context) ForEachKey(pattern string, secretOnly bool, fn func(*Key) error) error {
+	if err := c.KeyListStart(pattern, secretOnly); err != nil {
+		return err
+	}
+	defer func() { _ = c.KeyListEnd() }()
+	for c.KeyListNext() {
+		if err := fn(c.Key); err != nil {
+			return err
+		}
+	}
+	return c.KeyError
+}
+
+// LocateKey looks up a key for email in the local keyring and, as configured
+// by auto-key-locate, in the Web Key Directory and on keyservers. The first
+// key that is not revoked, expired, disabled or invalid is returned.
+func (c *Context) LocateKey(email string) (*Key, error) {
+	mode := c.KeyListMode()
+	if err := c.SetKeyListMode(mode | KeyListModeLocate); err != nil {
+		return nil, err
+	}
+	defer func() { _ = c.SetKeyListMode(mode) }()
+
+	if err := c.KeyListStart(email, false); err != nil {
+		return nil, err
+	}
+	defer func() { _ = c.KeyListEnd() }()
+	for c.KeyListNext() {
+		k := c.Key
+		if k.Revoked() || k.Expired() || k.Disabled() || k.Invalid() {
+			continue
+		}
+		return k, nil
+	}
+	if c.KeyError != nil {
+		return nil, c.KeyError
+	}
+	return nil, fmt.Errorf("key for %q not found", email)
+}
+
 func (c *Context) GetKey(fingerprint string, secret bool) (*Key, error) {
-	key := newKey()
+	key := newKey(c)
 	cfpr := C.CString(fingerprint)
 	defer C.free(unsafe.Pointer(cfpr))
 	err := handleError(C.gpgme_get_key(c.ctx, cfpr, &key.k, cbool(secret)))
@@ -466,19 +1319,194 @@ func (c *Context) GetKey(fingerprint string, secret bool) (*Key, error) {
 }
 
 func (c *Context) Decrypt(ciphertext, plaintext *Data) error {
-	err := handleError(C.gpgme_op_decrypt(c.ctx, ciphertext.dh, plaintext.dh))
+	out, done, err := c.limitPlaintext(plaintext)
+	if err != nil {
+		return err
+	}
+	err = handleError(C.gpgme_op_decrypt(c.ctx, ciphertext.dh, out.dh))
 	runtime.KeepAlive(c)
 	runtime.KeepAlive(ciphertext)
-	runtime.KeepAlive(plaintext)
-	return err
+	runtime.KeepAlive(out)
+	return done(err)
+}
+
+// ErrPlaintextTooLarge is returned by decryption operations when the
+// plaintext exceeds the limit set with SetMaxPlaintextBytes
+var ErrPlaintextTooLarge = errors.New("plaintext exceeds size limit")
+
+// SetMaxPlaintextBytes limits the size of the plaintext produced by Decrypt,
+// DecryptVerify and DecryptExt to n bytes. An operation exceeding it is
+// aborted with ErrPlaintextTooLarge, protecting against compressed messages
+// that expand to huge plaintexts. The plaintext up to the limit is written,
+// it is not removed. Zero or less disables the limit, the default. Archives
+// extracted with DecryptToDir are written by gpgtar and are not limited.
+func (c *Context) SetMaxPlaintextBytes(n int64) {
+	c.maxPlaintext = n
+}
+
+func (c *Context) MaxPlaintextBytes() int64 {
+	return c.maxPlaintext
+}
+
+// limitPlaintext returns the data to decrypt into in place of plaintext,
+// enforcing the limit of SetMaxPlaintextBytes, and a function to be called
+// with the operation's error that releases it and returns the error to
+// report. The name and encoding of plaintext are copied to the replacement.
+func (c *Context) limitPlaintext(plaintext *Data) (*Data, func(error) error, error) {
+	if c.maxPlaintext <= 0 {
+		return plaintext, func(err error) error { return err }, nil
+	}
+	lw := &limitWriter{w: plaintext, n: c.maxPlaintext}
+	out, err := NewDataWriter(lw)
+	if err != nil {
+		return nil, nil, err
+	}
+	if name := plaintext.Name(); name != "" {
+		if err := out.SetName(name); err != nil {
+			out.Close()
+			return nil, nil, err
+		}
+	}
+	if err := out.SetEncoding(plaintext.Encoding()); err != nil {
+		out.Close()
+		return nil, nil, err
+	}
+	return out, func(err error) error {
+		out.Close()
+		if lw.exceeded {
+			return ErrPlaintextTooLarge
+		}
+		return err
+	}, nil
+}
+
+// limitWriter writes at most n bytes to w and fails writes beyond that
+type limitWriter struct {
+	w        io.Writer
+	n        int64
+	exceeded bool
+}
+
+func (l *limitWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) <= l.n {
+		n, err := l.w.Write(p)
+		l.n -= int64(n)
+		return n, err
+	}
+	l.exceeded = true
+	n, err := l.w.Write(p[:l.n])
+	l.n -= int64(n)
+	if err == nil {
+		err = ErrPlaintextTooLarge
+	}
+	return n, err
+}
+
+// Recipient is a key a message was encrypted to
+type Recipient struct {
+	KeyID      string
+	PubkeyAlgo PubkeyAlgo
+	// Status is nil if the secret key is available and the message could be
+	// decrypted with it
+	Status error
+}
+
+// DecryptResult is the result of a decryption operation
+type DecryptResult struct {
+	// UnsupportedAlgorithm names the algorithm if decryption failed because
+	// it is not supported
+	UnsupportedAlgorithm string
+	// WrongKeyUsage is set if the key used for decryption is not an
+	// encryption key
+	WrongKeyUsage bool
+	// LegacyCipherNoMDC is set if the message was encrypted with a legacy
+	// cipher without integrity protection, which makes it suspect
+	LegacyCipherNoMDC bool
+	// IsMIME is set if the plaintext is flagged as MIME data
+	IsMIME bool
+	// IsDeVs is set if the message complies with the VS-NfD rules
+	IsDeVs bool
+	// FileName is the original file name of the plaintext, if stored
+	FileName string
+	// SymkeyAlgo describes the symmetric algorithm and mode, e.g. "9.2" for
+	// AES256.OCB
+	SymkeyAlgo string
+	// SessionKey is the session key of the message, as "<algo>:<hex key>",
+	// if SetExportSessionKey is enabled
+	SessionKey string
+	Recipients []Recipient
+}
+
+// DecryptResult returns the result of the last decryption on the context
+func (c *Context) DecryptResult() *DecryptResult {
+	res := C.gpgme_op_decrypt_result(c.ctx)
+	runtime.KeepAlive(c)
+	// NOTE: c must be live as long as we are accessing res.
+	r := &DecryptResult{}
+	if res != nil {
+		r.UnsupportedAlgorithm = C.GoString(res.unsupported_algorithm)
+		r.WrongKeyUsage = C.decrypt_result_wrong_key_usage(res) != 0
+		r.LegacyCipherNoMDC = C.decrypt_result_legacy_cipher_nomdc(res) != 0
+		r.IsMIME = C.decrypt_result_is_mime(res) != 0
+		r.IsDeVs = C.decrypt_result_is_de_vs(res) != 0
+		r.FileName = C.GoString(res.file_name)
+		r.SymkeyAlgo = C.GoString(res.symkey_algo)
+		r.SessionKey = C.GoString(res.session_key)
+		for rc := res.recipients; rc != nil; rc = rc.next {
+			r.Recipients = append(r.Recipients, Recipient{
+				KeyID:      C.GoString(rc.keyid),
+				PubkeyAlgo: PubkeyAlgo(rc.pubkey_algo),
+				Status:     handleError(rc.status),
+			})
+		}
+	}
+	runtime.KeepAlive(c) // for all accesses to res above
+	return r
+}
+
+// DecryptExt decrypts ciphertext into plaintext as modified by flags. With
+// DecryptVerify the signatures of the plaintext are verified in the same pass
+// and returned. The decrypt result is returned even if decryption fails.
+func (c *Context) DecryptExt(flags DecryptFlag, ciphertext, plaintext *Data) (*DecryptResult, []Signature, error) {
+	return c.decryptExt(flags, ciphertext, plaintext, true)
+}
+
+// decryptExt is DecryptExt, enforcing the limit of SetMaxPlaintextBytes only
+// if limit is set.
+func (c *Context) decryptExt(flags DecryptFlag, ciphertext, plaintext *Data, limit bool) (*DecryptResult, []Signature, error) {
+	out, done := plaintext, func(err error) error { return err }
+	if limit {
+		var err error
+		if out, done, err = c.limitPlaintext(plaintext); err != nil {
+			return nil, nil, err
+		}
+	}
+	err := handleError(C.gpgme_op_decrypt_ext(c.ctx, C.gpgme_decrypt_flags_t(flags), ciphertext.dh, out.dh))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(ciphertext)
+	runtime.KeepAlive(out)
+	err = done(err)
+	res := c.DecryptResult()
+	if err != nil {
+		return res, nil, err
+	}
+	var sigs []Signature
+	if flags&DecryptVerify != 0 {
+		_, sigs = c.verifyResult()
+	}
+	return res, sigs, nil
 }
 
 func (c *Context) DecryptVerify(ciphertext, plaintext *Data) error {
-	err := handleError(C.gpgme_op_decrypt_verify(c.ctx, ciphertext.dh, plaintext.dh))
+	out, done, err := c.limitPlaintext(plaintext)
+	if err != nil {
+		return err
+	}
+	err = handleError(C.gpgme_op_decrypt_verify(c.ctx, ciphertext.dh, out.dh))
 	runtime.KeepAlive(c)
 	runtime.KeepAlive(ciphertext)
-	runtime.KeepAlive(plaintext)
-	return err
+	runtime.KeepAlive(out)
+	return done(err)
 }
 
 type Signature struct {
@@ -494,6 +1522,13 @@ type Signature struct {
 	ValidityReason error
 	PubkeyAlgo     PubkeyAlgo
 	HashAlgo       HashAlgo
+	// IsDeVs is set if the signature complies with the VS-NfD rules
+	IsDeVs bool
+	// Key is the signing key if the engine provided it, e.g. when it was
+	// retrieved during verification
+	Key *Key
+	// Notations are the notation data and policy URLs of the signature
+	Notations []SigNotation
 }
 
 func (c *Context) Verify(sig, signedText, plain *Data) (string, []Signature, error) {
@@ -516,16 +1551,21 @@ func (c *Context) Verify(sig, signedText, plain *Data) (string, []Signature, err
 	if err != nil {
 		return "", nil, err
 	}
+	fileName, sigs := c.verifyResult()
+	return fileName, sigs, nil
+}
+
+// verifyResult returns the file name and signatures of the last verification
+func (c *Context) verifyResult() (string, []Signature) {
 	res := C.gpgme_op_verify_result(c.ctx)
 	runtime.KeepAlive(c)
 	// NOTE: c must be live as long as we are accessing res.
 	sigs := []Signature{}
 	for s := res.signatures; s != nil; s = s.next {
 		sig := Signature{
-			Summary:     SigSum(s.summary),
-			Fingerprint: C.GoString(s.fpr),
-			Status:      handleError(s.status),
-			// s.notations not implemented
+			Summary:        SigSum(s.summary),
+			Fingerprint:    C.GoString(s.fpr),
+			Status:         handleError(s.status),
 			Timestamp:      time.Unix(int64(s.timestamp), 0),
 			ExpTimestamp:   time.Unix(int64(s.exp_timestamp), 0),
 			WrongKeyUsage:  C.signature_wrong_key_usage(s) != 0,
@@ -535,48 +1575,482 @@ func (c *Context) Verify(sig, signedText, plain *Data) (string, []Signature, err
 			ValidityReason: handleError(s.validity_reason),
 			PubkeyAlgo:     PubkeyAlgo(s.pubkey_algo),
 			HashAlgo:       HashAlgo(s.hash_algo),
+			IsDeVs:         C.signature_is_de_vs(s) != 0,
+			Notations:      sigNotations(s.notations),
+		}
+		if s.key != nil {
+			sig.Key = newKey(c)
+			C.gpgme_key_ref(s.key)
+			sig.Key.k = s.key
 		}
 		sigs = append(sigs, sig)
 	}
 	fileName := C.GoString(res.file_name)
 	runtime.KeepAlive(c) // for all accesses to res above
-	return fileName, sigs, nil
+	return fileName, sigs
+}
+
+// ResolveSignerKeys sets the Key of each of sigs that has none to the key
+// that made it, listing all signer keys in a single pass. Signatures whose
+// key is not in the keyring are left unchanged. It must not be called during
+// a key listing.
+func (c *Context) ResolveSignerKeys(sigs []Signature) error {
+	var patterns []string
+	for i := range sigs {
+		if sigs[i].Key == nil && sigs[i].Fingerprint != "" {
+			patterns = append(patterns, sigs[i].Fingerprint)
+		}
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+	if err := c.KeyListStartExt(patterns, false); err != nil {
+		return err
+	}
+	bySubKey := make(map[string]*Key)
+	for c.KeyListNext() {
+		for sk := c.Key.SubKeys(); sk != nil; sk = sk.Next() {
+			bySubKey[strings.ToUpper(sk.Fingerprint())] = c.Key
+			bySubKey[strings.ToUpper(sk.KeyID())] = c.Key
+		}
+	}
+	if c.KeyError != nil {
+		_ = c.KeyListEnd()
+		return c.KeyError
+	}
+	if err := c.KeyListEnd(); err != nil {
+		return err
+	}
+	for i := range sigs {
+		if sigs[i].Key == nil {
+			sigs[i].Key = bySubKey[strings.ToUpper(sigs[i].Fingerprint)]
+		}
+	}
+	return nil
 }
 
-func (c *Context) Encrypt(recipients []*Key, flags EncryptFlag, plaintext, ciphertext *Data) error {
+// cKeys returns a NULL-terminated C array of keys, which must be freed with
+// C.free. The keys must be kept alive while the array is in use.
+func cKeys(keys []*Key) *C.gpgme_key_t {
 	size := unsafe.Sizeof(new(C.gpgme_key_t))
-	recp := C.calloc(C.size_t(len(recipients)+1), C.size_t(size))
-	defer C.free(recp)
-	for i := range recipients {
-		ptr := (*C.gpgme_key_t)(unsafe.Pointer(uintptr(recp) + size*uintptr(i)))
-		*ptr = recipients[i].k
+	arr := C.calloc(C.size_t(len(keys)+1), C.size_t(size))
+	for i := range keys {
+		ptr := (*C.gpgme_key_t)(unsafe.Pointer(uintptr(arr) + size*uintptr(i)))
+		*ptr = keys[i].k
+	}
+	return (*C.gpgme_key_t)(arr)
+}
+
+// cRecipients is like cKeys but returns nil for no recipients with
+// EncryptSymmetric, which gpgme takes to mean symmetric encryption only.
+// Without EncryptSymmetric an empty list is passed and rejected by gpgme.
+func cRecipients(recipients []*Key, flags EncryptFlag) *C.gpgme_key_t {
+	if len(recipients) == 0 && flags&EncryptSymmetric != 0 {
+		return nil
 	}
-	err := C.gpgme_op_encrypt(c.ctx, (*C.gpgme_key_t)(recp), C.gpgme_encrypt_flags_t(flags), plaintext.dh, ciphertext.dh)
+	return cKeys(recipients)
+}
+
+// Encrypt encrypts plaintext for recipients. With EncryptSymmetric the
+// ciphertext can also be decrypted with a passphrase, and recipients may be
+// empty to encrypt with a passphrase only.
+func (c *Context) Encrypt(recipients []*Key, flags EncryptFlag, plaintext, ciphertext *Data) error {
+	recp := cRecipients(recipients, flags)
+	defer C.free(unsafe.Pointer(recp))
+	err := handleError(C.gpgme_op_encrypt(c.ctx, recp, C.gpgme_encrypt_flags_t(flags), plaintext.dh, ciphertext.dh))
 	runtime.KeepAlive(c)
 	runtime.KeepAlive(recipients)
 	runtime.KeepAlive(plaintext)
 	runtime.KeepAlive(ciphertext)
-	return handleError(err)
+	if err != nil {
+		return invalidKeysError(err, c.EncryptResult().InvalidRecipients)
+	}
+	return nil
+}
+
+// EncryptExt encrypts plaintext for recipients given as strings instead of
+// keys. Each recipient is a fingerprint, key ID or user ID as understood by
+// gpg; a recipient prefixed with "--hidden " is not named in the
+// ciphertext, and "--" makes the following recipients plain strings even if
+// they start with "--".
+func (c *Context) EncryptExt(recipients []string, flags EncryptFlag, plaintext, ciphertext *Data) error {
+	crecp := C.CString(strings.Join(recipients, "\n"))
+	defer C.free(unsafe.Pointer(crecp))
+	err := handleError(C.gpgme_op_encrypt_ext(c.ctx, nil, crecp, C.gpgme_encrypt_flags_t(flags), plaintext.dh, ciphertext.dh))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(plaintext)
+	runtime.KeepAlive(ciphertext)
+	if err != nil {
+		return invalidKeysError(err, c.EncryptResult().InvalidRecipients)
+	}
+	return nil
+}
+
+// InvalidKey is a key that could not be used for an operation
+type InvalidKey struct {
+	Fingerprint string
+	Reason      error
+}
+
+func invalidKeys(k C.gpgme_invalid_key_t) []InvalidKey {
+	var keys []InvalidKey
+	for ; k != nil; k = k.next {
+		keys = append(keys, InvalidKey{
+			Fingerprint: C.GoString(k.fpr),
+			Reason:      handleError(k.reason),
+		})
+	}
+	return keys
+}
+
+// InvalidKeysError is returned when an operation fails and the engine
+// reported keys it could not use. It wraps the error of the operation.
+type InvalidKeysError struct {
+	Err  error
+	Keys []InvalidKey
+}
+
+func (e *InvalidKeysError) Error() string {
+	msgs := make([]string, len(e.Keys))
+	for i, k := range e.Keys {
+		msgs[i] = fmt.Sprintf("%s: %v", k.Fingerprint, k.Reason)
+	}
+	return fmt.Sprintf("%v: invalid keys: %s", e.Err, strings.Join(msgs, ", "))
+}
+
+func (e *InvalidKeysError) Unwrap() error {
+	return e.Err
+}
+
+// invalidKeysError wraps err in an InvalidKeysError if there are invalid keys
+func invalidKeysError(err error, keys []InvalidKey) error {
+	if len(keys) == 0 {
+		return err
+	}
+	return &InvalidKeysError{Err: err, Keys: keys}
+}
+
+// EncryptResult is the result of an encryption operation
+type EncryptResult struct {
+	InvalidRecipients []InvalidKey
+}
+
+// EncryptResult returns the result of the last encryption on the context
+func (c *Context) EncryptResult() *EncryptResult {
+	res := C.gpgme_op_encrypt_result(c.ctx)
+	runtime.KeepAlive(c)
+	// NOTE: c must be live as long as we are accessing res.
+	r := &EncryptResult{}
+	if res != nil {
+		r.InvalidRecipients = invalidKeys(res.invalid_recipients)
+	}
+	runtime.KeepAlive(c) // for all accesses to res above
+	return r
+}
+
+// NewSignature is a signature created by a signing operation
+type NewSignature struct {
+	Type        SigMode
+	PubkeyAlgo  PubkeyAlgo
+	HashAlgo    HashAlgo
+	Class       uint
+	Timestamp   time.Time
+	Fingerprint string
+}
+
+// SignResult is the result of a signing operation
+type SignResult struct {
+	InvalidSigners []InvalidKey
+	Signatures     []NewSignature
+}
+
+// SignResult returns the result of the last signing operation on the
+// context, including the signatures that were created
+func (c *Context) SignResult() *SignResult {
+	res := C.gpgme_op_sign_result(c.ctx)
+	runtime.KeepAlive(c)
+	// NOTE: c must be live as long as we are accessing res.
+	r := &SignResult{}
+	if res != nil {
+		r.InvalidSigners = invalidKeys(res.invalid_signers)
+		for s := res.signatures; s != nil; s = s.next {
+			r.Signatures = append(r.Signatures, NewSignature{
+				Type:        SigMode(s._type),
+				PubkeyAlgo:  PubkeyAlgo(s.pubkey_algo),
+				HashAlgo:    HashAlgo(s.hash_algo),
+				Class:       uint(s.sig_class),
+				Timestamp:   unixTime(int64(s.timestamp)),
+				Fingerprint: C.GoString(s.fpr),
+			})
+		}
+	}
+	runtime.KeepAlive(c) // for all accesses to res above
+	return r
+}
+
+// EncryptSign signs plaintext with signers and encrypts it for recipients in
+// one operation, writing a single message to ciphertext. If signers is nil
+// the signers already set on the context are used. The results are returned
+// even if the operation fails, so invalid recipients and signers can be
+// reported.
+func (c *Context) EncryptSign(recipients, signers []*Key, flags EncryptFlag, plaintext, ciphertext *Data) (*EncryptResult, *SignResult, error) {
+	if signers != nil {
+		if err := c.setSigners(signers); err != nil {
+			return nil, nil, err
+		}
+	}
+	recp := cRecipients(recipients, flags)
+	defer C.free(unsafe.Pointer(recp))
+	err := handleError(C.gpgme_op_encrypt_sign(c.ctx, recp, C.gpgme_encrypt_flags_t(flags), plaintext.dh, ciphertext.dh))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(recipients)
+	runtime.KeepAlive(plaintext)
+	runtime.KeepAlive(ciphertext)
+	encResult, signResult := c.EncryptResult(), c.SignResult()
+	if err != nil {
+		var invalid []InvalidKey
+		invalid = append(invalid, encResult.InvalidRecipients...)
+		invalid = append(invalid, signResult.InvalidSigners...)
+		err = invalidKeysError(err, invalid)
+	}
+	return encResult, signResult, err
+}
+
+// setSigners replaces the signers of the context with signers
+func (c *Context) setSigners(signers []*Key) error {
+	c.SignersClear()
+	for _, k := range signers {
+		if err := c.SignersAdd(k); err != nil {
+			c.SignersClear()
+			return err
+		}
+	}
+	return nil
+}
+
+// SignersClear removes all signers from the context
+func (c *Context) SignersClear() {
+	C.gpgme_signers_clear(c.ctx)
+	runtime.KeepAlive(c)
+	c.unpinSubkeys()
+}
+
+// SignersAdd adds key to the signers of the context, which are used by
+// KeySign and by EncryptSign with nil signers. Sign replaces them.
+func (c *Context) SignersAdd(key *Key) error {
+	err := handleError(C.gpgme_signers_add(c.ctx, key.k))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	return err
+}
+
+// SignersAddFingerprint looks up the secret key fpr and adds it to the
+// signers. The engine chooses the signing subkey of the key, even if fpr is
+// that of another subkey, unless fpr is followed by "!" as with gpg
+// --local-user: then exactly the subkey fpr signs. Exact subkeys are only
+// supported with ProtocolOpenPGP.
+func (c *Context) SignersAddFingerprint(fpr string) error {
+	exact := strings.HasSuffix(fpr, "!")
+	fpr = strings.TrimSuffix(fpr, "!")
+	if exact && c.Protocol() != ProtocolOpenPGP {
+		return fmt.Errorf("selecting the exact subkey %s! requires ProtocolOpenPGP", fpr)
+	}
+	key, err := c.GetKey(fpr, true)
+	if err != nil {
+		return err
+	}
+	if exact {
+		if err := c.pinSubkey(key, fpr); err != nil {
+			return err
+		}
+	}
+	return c.SignersAdd(key)
+}
+
+// pinSubkey makes the engine sign with the subkey fpr of key. GPGME passes the
+// key ID of the first subkey of a signer to gpg --local-user, so it is
+// replaced by "fpr!" until the signers are cleared. key must not be shared,
+// as by a fresh GetKey.
+func (c *Context) pinSubkey(key *Key, fpr string) error {
+	var sub *SubKey
+	for s := key.SubKeys(); s != nil; s = s.Next() {
+		if strings.EqualFold(s.Fingerprint(), fpr) {
+			sub = s
+			break
+		}
+	}
+	if sub == nil {
+		return fmt.Errorf("key %s has no subkey %s", key.SubKeys().Fingerprint(), fpr)
+	}
+	key.pinnedKeyID = C.CString(sub.Fingerprint() + "!")
+	key.k.subkeys.keyid = key.pinnedKeyID
+	runtime.KeepAlive(key)
+	c.pinnedSigners = append(c.pinnedSigners, key)
+	return nil
+}
+
+// unpinSubkeys restores the key IDs replaced by pinSubkey.
+func (c *Context) unpinSubkeys() {
+	for _, key := range c.pinnedSigners {
+		key.unpin()
+	}
+	c.pinnedSigners = nil
+}
+
+// SignersCount returns the number of signers of the context
+func (c *Context) SignersCount() int {
+	n := int(C.gpgme_signers_count(c.ctx))
+	runtime.KeepAlive(c)
+	return n
+}
+
+// SignersEnumerate returns the signers of the context in the order they were
+// added
+func (c *Context) SignersEnumerate() []*Key {
+	var keys []*Key
+	for i := 0; ; i++ {
+		k := C.gpgme_signers_enum(c.ctx, C.int(i))
+		runtime.KeepAlive(c)
+		if k == nil {
+			break
+		}
+		key := newKey(c)
+		key.k = k // gpgme_signers_enum acquired a reference
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// SigNotationFlag specifies options for SigNotationAdd
+type SigNotationFlag uint
+
+const (
+	SigNotationHumanReadable SigNotationFlag = C.GPGME_SIG_NOTATION_HUMAN_READABLE
+	SigNotationCritical      SigNotationFlag = C.GPGME_SIG_NOTATION_CRITICAL
+)
+
+// SigNotationAdd adds a notation to the signatures created with the context.
+// An empty name adds value as a policy URL. Notation names must be of the form
+// "name@domain" unless defined by the OpenPGP standard.
+func (c *Context) SigNotationAdd(name, value string, flags SigNotationFlag) error {
+	var cname *C.char
+	if name != "" {
+		cname = C.CString(name)
+		defer C.free(unsafe.Pointer(cname))
+	}
+	cvalue := C.CString(value)
+	defer C.free(unsafe.Pointer(cvalue))
+	err := handleError(C.gpgme_sig_notation_add(c.ctx, cname, cvalue, C.gpgme_sig_notation_flags_t(flags)))
+	runtime.KeepAlive(c)
+	return err
+}
+
+// SigNotationClear removes all notations added with SigNotationAdd
+func (c *Context) SigNotationClear() {
+	C.gpgme_sig_notation_clear(c.ctx)
+	runtime.KeepAlive(c)
+}
+
+// SigNotations returns the notations added with SigNotationAdd
+func (c *Context) SigNotations() []SigNotation {
+	n := C.gpgme_sig_notation_get(c.ctx)
+	runtime.KeepAlive(c)
+	// NOTE: c must be live as long as we are accessing n.
+	res := sigNotations(n)
+	runtime.KeepAlive(c)
+	return res
+}
+
+func (c *Context) Sign(signers []*Key, plain, sig *Data, mode SigMode) error {
+	if err := c.setSigners(signers); err != nil {
+		return err
+	}
+	err := handleError(C.gpgme_op_sign(c.ctx, plain.dh, sig.dh, C.gpgme_sig_mode_t(mode)))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(plain)
+	runtime.KeepAlive(sig)
+	if err != nil {
+		return invalidKeysError(err, c.SignResult().InvalidSigners)
+	}
+	return nil
+}
+
+// ClearSign signs the text read from r with signers, writing a cleartext
+// signed message, readable without OpenPGP software, to w.
+func (c *Context) ClearSign(signers []*Key, r io.Reader, w io.Writer) error {
+	plain, err := NewDataReader(r)
+	if err != nil {
+		return err
+	}
+	defer plain.Close()
+	sig, err := NewDataWriter(w)
+	if err != nil {
+		return err
+	}
+	defer sig.Close()
+	return c.Sign(signers, plain, sig, SigModeClear)
+}
+
+// InteractFlag specifies options for Interact
+type InteractFlag uint
+
+const (
+	InteractCard InteractFlag = C.GPGME_INTERACT_CARD
+)
+
+// InteractCallback is called for every status line the engine emits during
+// Interact. w is nil unless the engine expects a response, which has to be
+// terminated by a newline.
+type InteractCallback func(keyword, args string, w io.Writer) error
+
+type interaction struct {
+	fn  InteractCallback
+	err error
+}
+
+// interactWriter writes the responses of an interaction to the engine
+type interactWriter C.int
+
+func (w interactWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	res, err := C.gpgme_io_writen(C.int(w), unsafe.Pointer(&p[0]), C.size_t(len(p)))
+	if res != 0 {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Interact runs an interactive key edit session like gpg --edit-key for key,
+// or for the smartcard with InteractCard. Every prompt of the engine is passed
+// to callback to be answered, any output is written to out if not nil.
+func (c *Context) Interact(key *Key, flags InteractFlag, callback InteractCallback, out *Data) error {
+	return c.interact(key, flags, callback, out)
+}
+
+func (c *Context) interact(key *Key, flags InteractFlag, fn InteractCallback, out *Data) error {
+	ia := &interaction{fn: fn}
+	h := cgo.NewHandle(ia)
+	defer h.Delete()
+	err := c.opInteract(key, flags, unsafe.Pointer(&h), out)
+	if ia.err != nil {
+		return ia.err
+	}
+	return err
 }
 
-func (c *Context) Sign(signers []*Key, plain, sig *Data, mode SigMode) error {
-	C.gpgme_signers_clear(c.ctx)
-	runtime.KeepAlive(c)
-	for _, k := range signers {
-		err := handleError(C.gpgme_signers_add(c.ctx, k.k))
-		runtime.KeepAlive(c)
-		runtime.KeepAlive(k)
-		if err != nil {
-			C.gpgme_signers_clear(c.ctx)
-			runtime.KeepAlive(c)
-			return err
-		}
+// respond passes a prompt of the engine to the interaction callback
+func (ia *interaction) respond(keyword, args string, fd C.int) C.gpgme_error_t {
+	var w io.Writer
+	if fd >= 0 {
+		w = interactWriter(fd)
 	}
-	err := handleError(C.gpgme_op_sign(c.ctx, plain.dh, sig.dh, C.gpgme_sig_mode_t(mode)))
-	runtime.KeepAlive(c)
-	runtime.KeepAlive(plain)
-	runtime.KeepAlive(sig)
-	return err
+	if err := ia.fn(keyword, args, w); err != nil {
+		ia.err = err
+		return C.gpgme_error(C.GPG_ERR_CANCELED)
+	}
+	return 0
 }
 
 type (
@@ -662,19 +2136,72 @@ func gogpgme_assuan_status_callback(handle unsafe.Pointer, cStatus *C.char, cArg
 type ExportModeFlags uint
 
 const (
-	ExportModeExtern  ExportModeFlags = C.GPGME_EXPORT_MODE_EXTERN
+	// ExportModeExtern sends the keys to the keyserver configured for the
+	// engine instead of writing them out. The data argument of Export and
+	// ExportExt must be nil when using this mode.
+	ExportModeExtern ExportModeFlags = C.GPGME_EXPORT_MODE_EXTERN
+	// ExportModeMinimal strips all signatures from the exported keys except
+	// for the latest self-signatures.
 	ExportModeMinimal ExportModeFlags = C.GPGME_EXPORT_MODE_MINIMAL
+	// ExportModeSecret exports secret keys. The engine asks for the
+	// passphrase of protected keys, so the pinentry mode and passphrase
+	// callback of the context need to be set up accordingly.
+	ExportModeSecret ExportModeFlags = C.GPGME_EXPORT_MODE_SECRET
+	// ExportModeSSH exports the authentication subkey of a single key in the
+	// format used by OpenSSH authorized_keys files.
+	ExportModeSSH ExportModeFlags = C.GPGME_EXPORT_MODE_SSH
 )
 
 func (c *Context) Export(pattern string, mode ExportModeFlags, data *Data) error {
 	pat := C.CString(pattern)
 	defer C.free(unsafe.Pointer(pat))
-	err := handleError(C.gpgme_op_export(c.ctx, pat, C.gpgme_export_mode_t(mode), data.dh))
+	var dataPtr C.gpgme_data_t
+	if data != nil {
+		dataPtr = data.dh
+	}
+	err := handleError(C.gpgme_op_export(c.ctx, pat, C.gpgme_export_mode_t(mode), dataPtr))
 	runtime.KeepAlive(c)
 	runtime.KeepAlive(data)
 	return err
 }
 
+// ExportExt exports the keys matching any of patterns into data. An empty
+// list of patterns exports all keys.
+func (c *Context) ExportExt(patterns []string, mode ExportModeFlags, data *Data) error {
+	pats := cstrings(patterns)
+	defer freeCStrings(pats)
+	var dataPtr C.gpgme_data_t
+	if data != nil {
+		dataPtr = data.dh
+	}
+	err := handleError(C.gpgme_op_export_ext(c.ctx, pats, C.gpgme_export_mode_t(mode), dataPtr))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(data)
+	return err
+}
+
+// ExportKeys writes keys to data. Unlike ExportExt the keys are selected
+// exactly, without matching patterns against the keyring.
+func (c *Context) ExportKeys(keys []*Key, mode ExportModeFlags, data *Data) error {
+	ckeys := cKeys(keys)
+	defer C.free(unsafe.Pointer(ckeys))
+	var dataPtr C.gpgme_data_t
+	if data != nil {
+		dataPtr = data.dh
+	}
+	err := handleError(C.gpgme_op_export_keys(c.ctx, ckeys, C.gpgme_export_mode_t(mode), dataPtr))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(keys)
+	runtime.KeepAlive(data)
+	return err
+}
+
+// SendKeys publishes the keys matching any of patterns to the keyserver
+// configured for the engine.
+func (c *Context) SendKeys(patterns ...string) error {
+	return c.ExportExt(patterns, ExportModeExtern, nil)
+}
+
 // ImportStatusFlags describes the type of ImportStatus.Status. The C API in gpgme.h simply uses "unsigned".
 type ImportStatusFlags uint
 
@@ -686,6 +2213,9 @@ const (
 	ImportSecret ImportStatusFlags = C.GPGME_IMPORT_SECRET
 )
 
+// ImportStatus describes the outcome of importing a single key. Result is
+// non-nil if the key could not be imported, Status describes what was
+// imported otherwise; a zero Status means the key was unchanged.
 type ImportStatus struct {
 	Fingerprint string
 	Result      error
@@ -705,7 +2235,9 @@ type ImportResult struct {
 	SecretRead      int
 	SecretImported  int
 	SecretUnchanged int
+	SkippedNewKeys  int
 	NotImported     int
+	SkippedV3Keys   int
 	Imports         []ImportStatus
 }
 
@@ -716,6 +2248,49 @@ func (c *Context) Import(keyData *Data) (*ImportResult, error) {
 	if err != nil {
 		return nil, err
 	}
+	return c.importResult(), nil
+}
+
+// ReceiveKeys fetches the keys with the given key IDs or fingerprints from the
+// keyserver configured for the engine and imports them.
+func (c *Context) ReceiveKeys(keyIDs []string) (*ImportResult, error) {
+	ids := cstrings(keyIDs)
+	defer freeCStrings(ids)
+	err := handleError(C.gpgme_op_receive_keys(c.ctx, ids))
+	runtime.KeepAlive(c)
+	if err != nil {
+		return nil, err
+	}
+	return c.importResult(), nil
+}
+
+// RefreshKeys fetches fresh copies of the local keys matching any of patterns
+// from the keyserver configured for the engine, picking up new signatures,
+// subkeys and revocations. All local keys are refreshed if no patterns are
+// given. The keys that changed are reported with a non-zero Status in the
+// Imports of the result.
+func (c *Context) RefreshKeys(patterns ...string) (*ImportResult, error) {
+	if err := c.KeyListStartExt(patterns, false); err != nil {
+		return nil, err
+	}
+	var fprs []string
+	for c.KeyListNext() {
+		fprs = append(fprs, c.Key.SubKeys().Fingerprint())
+	}
+	if c.KeyError != nil {
+		_ = c.KeyListEnd()
+		return nil, c.KeyError
+	}
+	if err := c.KeyListEnd(); err != nil {
+		return nil, err
+	}
+	if len(fprs) == 0 {
+		return &ImportResult{Imports: []ImportStatus{}}, nil
+	}
+	return c.ReceiveKeys(fprs)
+}
+
+func (c *Context) importResult() *ImportResult {
 	res := C.gpgme_op_import_result(c.ctx)
 	runtime.KeepAlive(c)
 	// NOTE: c must be live as long as we are accessing res.
@@ -740,29 +2315,394 @@ func (c *Context) Import(keyData *Data) (*ImportResult, error) {
 		SecretRead:      int(res.secret_read),
 		SecretImported:  int(res.secret_imported),
 		SecretUnchanged: int(res.secret_unchanged),
+		SkippedNewKeys:  int(res.skipped_new_keys),
 		NotImported:     int(res.not_imported),
+		SkippedV3Keys:   int(res.skipped_v3_keys),
 		Imports:         imports,
 	}
 	runtime.KeepAlive(c) // for all accesses to res above
-	return importResult, nil
+	return importResult
+}
+
+// CreateFlag specifies options for the key creation operations
+type CreateFlag uint
+
+const (
+	CreateSign         CreateFlag = C.GPGME_CREATE_SIGN
+	CreateEncrypt      CreateFlag = C.GPGME_CREATE_ENCR
+	CreateCertify      CreateFlag = C.GPGME_CREATE_CERT
+	CreateAuthenticate CreateFlag = C.GPGME_CREATE_AUTH
+	CreateNoPassword   CreateFlag = C.GPGME_CREATE_NOPASSWD
+	CreateSelfSigned   CreateFlag = C.GPGME_CREATE_SELFSIGNED
+	CreateNoStore      CreateFlag = C.GPGME_CREATE_NOSTORE
+	CreateWantPublic   CreateFlag = C.GPGME_CREATE_WANTPUB
+	CreateWantSecret   CreateFlag = C.GPGME_CREATE_WANTSEC
+	CreateForce        CreateFlag = C.GPGME_CREATE_FORCE
+	CreateNoExpire     CreateFlag = C.GPGME_CREATE_NOEXPIRE
+)
+
+// expiresIn converts an expiration time into the number of seconds from now
+// expected by the key management operations. The zero time is passed as 0,
+// which lets the engine pick its default.
+func expiresIn(expires time.Time) C.ulong {
+	if expires.IsZero() {
+		return 0
+	}
+	secs := int64(time.Until(expires) / time.Second)
+	if secs < 1 {
+		secs = 1
+	}
+	return C.ulong(secs)
+}
+
+// GenKeyResult is the result of a key generation operation
+type GenKeyResult struct {
+	// Fingerprint of the created key, if available
+	Fingerprint string
+	// Primary is set if a primary key was created
+	Primary bool
+	// Sub is set if a subkey was created
+	Sub bool
+	// UID is set if a user ID was created
+	UID bool
+}
+
+func (c *Context) genKeyResult() *GenKeyResult {
+	res := C.gpgme_op_genkey_result(c.ctx)
+	runtime.KeepAlive(c)
+	// NOTE: c must be live as long as we are accessing res.
+	r := &GenKeyResult{
+		Fingerprint: C.GoString(res.fpr),
+		Primary:     C.genkey_result_primary(res) != 0,
+		Sub:         C.genkey_result_sub(res) != 0,
+		UID:         C.genkey_result_uid(res) != 0,
+	}
+	runtime.KeepAlive(c) // for all accesses to res above
+	return r
+}
+
+// CreateKey generates a new OpenPGP key for userID. algo is an algorithm
+// string as understood by gpg, such as "ed25519" or "rsa4096"; an empty string
+// selects the engine default.
+func (c *Context) CreateKey(userID, algo string, expires time.Time, flags CreateFlag) (*GenKeyResult, error) {
+	cuid := C.CString(userID)
+	defer C.free(unsafe.Pointer(cuid))
+	var calgo *C.char
+	if algo != "" {
+		calgo = C.CString(algo)
+		defer C.free(unsafe.Pointer(calgo))
+	}
+	err := handleError(C.gpgme_op_createkey(c.ctx, cuid, calgo, 0, expiresIn(expires), nil, C.uint(flags)))
+	runtime.KeepAlive(c)
+	if err != nil {
+		return nil, err
+	}
+	return c.genKeyResult(), nil
+}
+
+// CreateSubkey adds a new subkey to key. The usage of the subkey is selected
+// with the CreateSign, CreateEncrypt and CreateAuthenticate flags.
+func (c *Context) CreateSubkey(key *Key, algo string, expires time.Time, flags CreateFlag) (*GenKeyResult, error) {
+	var calgo *C.char
+	if algo != "" {
+		calgo = C.CString(algo)
+		defer C.free(unsafe.Pointer(calgo))
+	}
+	err := handleError(C.gpgme_op_createsubkey(c.ctx, key.k, calgo, 0, expiresIn(expires), C.uint(flags)))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	if err != nil {
+		return nil, err
+	}
+	return c.genKeyResult(), nil
+}
+
+// GenKey generates a key from a parameter block in the format used by gpg
+// --generate-key --batch, e.g.
+//
+//	<GnupgKeyParms format="internal">
+//	Key-Type: RSA
+//	Key-Length: 3072
+//	Name-Real: Joe Tester
+//	Name-Email: joe@example.com
+//	Expire-Date: 0
+//	</GnupgKeyParms>
+//
+// It works with engines that lack CreateKey. For OpenPGP pubkey and seckey
+// must be nil; for CMS pubkey receives the certificate request.
+func (c *Context) GenKey(params string, pubkey, seckey *Data) (*GenKeyResult, error) {
+	cparams := C.CString(params)
+	defer C.free(unsafe.Pointer(cparams))
+	var pubPtr, secPtr C.gpgme_data_t
+	if pubkey != nil {
+		pubPtr = pubkey.dh
+	}
+	if seckey != nil {
+		secPtr = seckey.dh
+	}
+	err := handleError(C.gpgme_op_genkey(c.ctx, cparams, pubPtr, secPtr))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(pubkey)
+	runtime.KeepAlive(seckey)
+	if err != nil {
+		return nil, err
+	}
+	return c.genKeyResult(), nil
+}
+
+// DeleteFlag specifies options for DeleteKey
+type DeleteFlag uint
+
+const (
+	DeleteAllowSecret DeleteFlag = C.GPGME_DELETE_ALLOW_SECRET
+	DeleteForce       DeleteFlag = C.GPGME_DELETE_FORCE
+)
+
+// DeleteKey removes key from the keyring. Deleting a key with a secret part
+// fails unless DeleteAllowSecret is given, DeleteForce additionally skips the
+// confirmation the engine would otherwise ask for.
+func (c *Context) DeleteKey(key *Key, flags DeleteFlag) error {
+	err := handleError(C.gpgme_op_delete_ext(c.ctx, key.k, C.uint(flags)))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	return err
+}
+
+// SetExpire changes the expiration time of key. If no subkeys are given the
+// primary key is changed, otherwise the subkeys with the given fingerprints;
+// "*" selects all subkeys. The zero time removes the expiration.
+func (c *Context) SetExpire(key *Key, expires time.Time, subkeys ...string) error {
+	var csub *C.char
+	if len(subkeys) > 0 {
+		csub = C.CString(strings.Join(subkeys, "\n"))
+		defer C.free(unsafe.Pointer(csub))
+	}
+	err := handleError(C.gpgme_op_setexpire(c.ctx, key.k, expiresIn(expires), csub, 0))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	return err
+}
+
+// AddUID adds the user ID userID to key
+func (c *Context) AddUID(key *Key, userID string) error {
+	cuid := C.CString(userID)
+	defer C.free(unsafe.Pointer(cuid))
+	err := handleError(C.gpgme_op_adduid(c.ctx, key.k, cuid, 0))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	return err
+}
+
+// RevUID revokes the user ID userID of key
+func (c *Context) RevUID(key *Key, userID string) error {
+	cuid := C.CString(userID)
+	defer C.free(unsafe.Pointer(cuid))
+	err := handleError(C.gpgme_op_revuid(c.ctx, key.k, cuid, 0))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	return err
+}
+
+// SetPrimaryUID marks the user ID userID as the primary user ID of key
+func (c *Context) SetPrimaryUID(key *Key, userID string) error {
+	cuid := C.CString(userID)
+	defer C.free(unsafe.Pointer(cuid))
+	cname := C.CString("primary")
+	defer C.free(unsafe.Pointer(cname))
+	err := handleError(C.gpgme_op_set_uid_flag(c.ctx, key.k, cuid, cname, nil))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	return err
+}
+
+// SetTofuPolicy sets the TOFU policy of key
+func (c *Context) SetTofuPolicy(key *Key, policy TofuPolicy) error {
+	err := handleError(C.gpgme_op_tofu_policy(c.ctx, key.k, C.gpgme_tofu_policy_t(policy)))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	return err
+}
+
+// SetOwnerTrust sets the owner trust of key, which states how far the owner
+// of the key is trusted to correctly certify other keys.
+func (c *Context) SetOwnerTrust(key *Key, trust Validity) error {
+	var value string
+	switch trust {
+	case ValidityUnknown, ValidityUndefined:
+		value = "1"
+	case ValidityNever:
+		value = "2"
+	case ValidityMarginal:
+		value = "3"
+	case ValidityFull:
+		value = "4"
+	case ValidityUltimate:
+		value = "5"
+	default:
+		return fmt.Errorf("invalid owner trust %d", trust)
+	}
+	trustSet := false
+	return c.interact(key, 0, func(keyword, args string, w io.Writer) error {
+		if w == nil {
+			return nil
+		}
+		var resp string
+		switch {
+		case keyword == "GET_LINE" && args == "keyedit.prompt":
+			resp = "trust"
+			if trustSet {
+				resp = "quit"
+			}
+		case keyword == "GET_LINE" && args == "edit_ownertrust.value":
+			resp = value
+			trustSet = true
+		case keyword == "GET_BOOL" && args == "edit_ownertrust.set_ultimate.okay",
+			keyword == "GET_BOOL" && args == "keyedit.save.okay":
+			resp = "Y"
+		default:
+			return fmt.Errorf("unexpected prompt %s %s", keyword, args)
+		}
+		_, err := io.WriteString(w, resp+"\n")
+		return err
+	}, nil)
+}
+
+// KeySignFlag specifies options for KeySign
+type KeySignFlag uint
+
+const (
+	KeySignLocal    KeySignFlag = C.GPGME_KEYSIGN_LOCAL
+	KeySignLFSep    KeySignFlag = C.GPGME_KEYSIGN_LFSEP
+	KeySignNoExpire KeySignFlag = C.GPGME_KEYSIGN_NOEXPIRE
+	KeySignForce    KeySignFlag = C.GPGME_KEYSIGN_FORCE
+)
+
+// KeySign certifies the user ID userID of key, or all of its user IDs if
+// userID is empty. With KeySignLFSep, userID may hold several user IDs
+// separated by line feeds. The certification is made with the first signer of
+// the context, or the default key if no signers are set.
+func (c *Context) KeySign(key *Key, userID string, expires time.Time, flags KeySignFlag) error {
+	var cuid *C.char
+	if userID != "" {
+		cuid = C.CString(userID)
+		defer C.free(unsafe.Pointer(cuid))
+	}
+	err := handleError(C.gpgme_op_keysign(c.ctx, key.k, cuid, expiresIn(expires), C.uint(flags)))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	return err
 }
 
 type Key struct {
 	k C.gpgme_key_t // WARNING: Call Runtime.KeepAlive(k) after ANY passing of k.k to C
+
+	engine *keyEngine // of the context the key was obtained from, see newContext
+
+	pinnedKeyID *C.char // see Context.pinSubkey
 }
 
-func newKey() *Key {
-	k := &Key{}
+func newKey(c *Context) *Key {
+	k := &Key{engine: c.keyEngine()}
 	runtime.SetFinalizer(k, (*Key).Release)
 	return k
 }
 
+// keyEngine is the engine configuration of a context, shared by the keys
+// obtained from it.
+type keyEngine struct {
+	fileName string
+	homeDir  string
+}
+
+// keyEngine returns the engine configuration of the protocol of c, which is
+// looked up once for all keys listed until the engine changes.
+func (c *Context) keyEngine() *keyEngine {
+	if c.engine == nil {
+		c.engine = &keyEngine{}
+		if info := c.EngineInfo().ForProtocol(c.Protocol()); info != nil {
+			c.engine.fileName, c.engine.homeDir = info.FileName(), info.HomeDir()
+		}
+	}
+	return c.engine
+}
+
 func (k *Key) Release() {
+	k.unpin()
 	C.gpgme_key_release(k.k)
 	runtime.KeepAlive(k)
 	k.k = nil
 }
 
+// unpin restores the key ID of the first subkey replaced by
+// Context.pinSubkey; gpgme_key_release does not free key IDs.
+func (k *Key) unpin() {
+	if k.pinnedKeyID == nil {
+		return
+	}
+	if k.k != nil {
+		k.k.subkeys.keyid = &k.k.subkeys._keyid[0]
+		runtime.KeepAlive(k)
+	}
+	C.free(unsafe.Pointer(k.pinnedKeyID))
+	k.pinnedKeyID = nil
+}
+
+// Armor returns the public key as ASCII armored text
+func (k *Key) Armor() (string, error) {
+	b, err := k.export(true)
+	return string(b), err
+}
+
+// Binary returns the public key in the binary OpenPGP format
+func (k *Key) Binary() ([]byte, error) {
+	return k.export(false)
+}
+
+// export exports k with a context from newContext, so that a key listing in
+// progress on the context the key was obtained from is not disturbed.
+func (k *Key) export(armor bool) ([]byte, error) {
+	ctx, err := k.newContext()
+	if err != nil {
+		return nil, err
+	}
+	defer ctx.Release()
+	ctx.SetArmor(armor)
+
+	data, err := NewData()
+	if err != nil {
+		return nil, err
+	}
+	defer data.Close()
+	if err := ctx.ExportKeys([]*Key{k}, 0, data); err != nil {
+		return nil, err
+	}
+	if _, err := data.Seek(0, SeekSet); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(data)
+}
+
+// newContext returns a new context with the protocol of k and the engine
+// info of the context k was obtained from, even if that has been released.
+func (k *Key) newContext() (*Context, error) {
+	ctx, err := New()
+	if err != nil {
+		return nil, err
+	}
+	proto := k.Protocol()
+	if err := ctx.SetProtocol(proto); err != nil {
+		ctx.Release()
+		return nil, err
+	}
+	if e := k.engine; e != nil && (e.fileName != "" || e.homeDir != "") {
+		if err := ctx.SetEngineInfo(proto, e.fileName, e.homeDir); err != nil {
+			ctx.Release()
+			return nil, err
+		}
+	}
+	return ctx, nil
+}
+
 func (k *Key) Revoked() bool {
 	res := C.key_revoked(k.k) != 0
 	runtime.KeepAlive(k)
@@ -829,18 +2769,25 @@ func (k *Key) Protocol() Protocol {
 	return res
 }
 
+// IssuerSerial returns the serial number of an X.509 certificate as
+// assigned by its issuer
 func (k *Key) IssuerSerial() string {
 	res := C.GoString(k.k.issuer_serial)
 	runtime.KeepAlive(k)
 	return res
 }
 
+// IssuerName returns the distinguished name of the issuer of an X.509
+// certificate
 func (k *Key) IssuerName() string {
 	res := C.GoString(k.k.issuer_name)
 	runtime.KeepAlive(k)
 	return res
 }
 
+// ChainID returns the fingerprint of the issuer certificate of an X.509
+// certificate, if available, or its own fingerprint for a root certificate.
+// See CertChain.
 func (k *Key) ChainID() string {
 	res := C.GoString(k.k.chain_id)
 	runtime.KeepAlive(k)
@@ -877,6 +2824,21 @@ func (k *Key) KeyListMode() KeyListMode {
 	return res
 }
 
+// Origin returns where the key was obtained from
+func (k *Key) Origin() KeyOrigin {
+	res := KeyOrigin(C.key_origin(k.k))
+	runtime.KeepAlive(k)
+	return res
+}
+
+// LastUpdate returns when the key was last updated from its origin, or the
+// zero time if unknown
+func (k *Key) LastUpdate() time.Time {
+	res := unixTime(int64(k.k.last_update))
+	runtime.KeepAlive(k)
+	return res
+}
+
 type SubKey struct {
 	k      C.gpgme_subkey_t
 	parent *Key // make sure the key is not released when we have a reference to a subkey
@@ -935,6 +2897,48 @@ func (k *SubKey) CardNumber() string {
 	return C.GoString(k.k.card_number)
 }
 
+// IsCardKey reports whether the secret key is stored on a smartcard
+func (k *SubKey) IsCardKey() bool {
+	return C.subkey_is_cardkey(k.k) != 0
+}
+
+// IsDeVs reports whether the subkey complies with the rules for classified
+// information in Germany at the restricted level (VS-NfD)
+func (k *SubKey) IsDeVs() bool {
+	return C.subkey_is_de_vs(k.k) != 0
+}
+
+// Keygrip returns the keygrip of the subkey, which identifies it in
+// gpg-agent. It is only available when listing with KeyListModeWithKeygrip.
+func (k *SubKey) Keygrip() string {
+	return C.GoString(k.k.keygrip)
+}
+
+// Curve returns the name of the elliptic curve for ECC keys
+func (k *SubKey) Curve() string {
+	return C.GoString(k.k.curve)
+}
+
+func (k *SubKey) PubkeyAlgo() PubkeyAlgo {
+	return PubkeyAlgo(k.k.pubkey_algo)
+}
+
+// Length returns the length of the key in bits
+func (k *SubKey) Length() uint {
+	return uint(k.k.length)
+}
+
+// AlgoString returns the algorithm and size or curve of the subkey in the
+// format used by gpg, e.g. "rsa2048" or "ed25519"
+func (k *SubKey) AlgoString() string {
+	cs := C.gpgme_pubkey_algo_string(k.k)
+	if cs == nil {
+		return ""
+	}
+	defer C.gpgme_free(unsafe.Pointer(cs))
+	return C.GoString(cs)
+}
+
 type UserID struct {
 	u      C.gpgme_user_id_t
 	parent *Key // make sure the key is not released when we have a reference to a user ID
@@ -974,3 +2978,171 @@ func (u *UserID) Comment() string {
 func (u *UserID) Email() string {
 	return C.GoString(u.u.email)
 }
+
+// Address returns the normalized addr-spec of the user ID
+func (u *UserID) Address() string {
+	return C.GoString(u.u.address)
+}
+
+// Origin returns where the user ID was obtained from
+func (u *UserID) Origin() KeyOrigin {
+	return KeyOrigin(C.uid_origin(u.u))
+}
+
+// LastUpdate returns when the user ID was last updated from its origin
+func (u *UserID) LastUpdate() time.Time {
+	return unixTime(int64(u.u.last_update))
+}
+
+// Signatures returns the first signature on the user ID. Signatures are only
+// available when listing with KeyListModeSigs.
+func (u *UserID) Signatures() *KeySig {
+	if u.u.signatures == nil {
+		return nil
+	}
+	return &KeySig{s: u.u.signatures, parent: u.parent}
+}
+
+// TofuInfo holds the TOFU statistics of a user ID. Validity is a value from
+// 0 (conflict) over 1 (no history) to 4 (a lot of history).
+type TofuInfo struct {
+	Validity    uint
+	Policy      TofuPolicy
+	SignCount   uint
+	EncrCount   uint
+	SignFirst   time.Time
+	SignLast    time.Time
+	EncrFirst   time.Time
+	EncrLast    time.Time
+	Description string
+}
+
+// TofuInfo returns the TOFU statistics of the user ID, or nil if the key was
+// not listed with KeyListModeWithTofu.
+func (u *UserID) TofuInfo() *TofuInfo {
+	t := u.u.tofu
+	if t == nil {
+		return nil
+	}
+	return &TofuInfo{
+		Validity:    uint(C.tofu_validity(t)),
+		Policy:      TofuPolicy(C.tofu_policy(t)),
+		SignCount:   uint(t.signcount),
+		EncrCount:   uint(t.encrcount),
+		SignFirst:   unixTime(int64(t.signfirst)),
+		SignLast:    unixTime(int64(t.signlast)),
+		EncrFirst:   unixTime(int64(t.encrfirst)),
+		EncrLast:    unixTime(int64(t.encrlast)),
+		Description: C.GoString(t.description),
+	}
+}
+
+// KeySig is a signature on a user ID
+type KeySig struct {
+	s      C.gpgme_key_sig_t
+	parent *Key // make sure the key is not released when we have a reference to a signature
+}
+
+func (s *KeySig) Next() *KeySig {
+	if s.s.next == nil {
+		return nil
+	}
+	return &KeySig{s: s.s.next, parent: s.parent}
+}
+
+// KeyID returns the key ID of the key that made the signature
+func (s *KeySig) KeyID() string {
+	return C.GoString(s.s.keyid)
+}
+
+// Status returns the error for signatures that could not be checked
+func (s *KeySig) Status() error {
+	return handleError(s.s.status)
+}
+
+// UID returns the main user ID of the key that made the signature
+func (s *KeySig) UID() string {
+	return C.GoString(s.s.uid)
+}
+
+func (s *KeySig) Name() string {
+	return C.GoString(s.s.name)
+}
+
+func (s *KeySig) Comment() string {
+	return C.GoString(s.s.comment)
+}
+
+func (s *KeySig) Email() string {
+	return C.GoString(s.s.email)
+}
+
+func (s *KeySig) Revoked() bool {
+	return C.key_sig_revoked(s.s) != 0
+}
+
+func (s *KeySig) Expired() bool {
+	return C.key_sig_expired(s.s) != 0
+}
+
+func (s *KeySig) Invalid() bool {
+	return C.key_sig_invalid(s.s) != 0
+}
+
+// Exportable reports whether the signature is exportable, i.e. not a local
+// signature
+func (s *KeySig) Exportable() bool {
+	return C.key_sig_exportable(s.s) != 0
+}
+
+func (s *KeySig) PubkeyAlgo() PubkeyAlgo {
+	return PubkeyAlgo(s.s.pubkey_algo)
+}
+
+// Timestamp returns the creation time of the signature
+func (s *KeySig) Timestamp() time.Time {
+	return unixTime(int64(s.s.timestamp))
+}
+
+// Expires returns the expiration time of the signature, or the zero time if
+// it does not expire
+func (s *KeySig) Expires() time.Time {
+	return unixTime(int64(s.s.expires))
+}
+
+// Class returns the signature class, e.g. 0x10 to 0x13 for certifications
+// and 0x30 for revocations
+func (s *KeySig) Class() uint {
+	return uint(s.s.sig_class)
+}
+
+// Notations returns the notation data and policy URLs of the signature.
+// Notations are only available when listing with KeyListModeSigNotations.
+func (s *KeySig) Notations() []SigNotation {
+	return sigNotations(s.s.notations)
+}
+
+// SigNotation is a notation or policy URL attached to a signature. Policy
+// URLs have an empty Name.
+type SigNotation struct {
+	Name          string
+	Value         string
+	HumanReadable bool
+	Critical      bool
+}
+
+func sigNotations(n C.gpgme_sig_notation_t) []SigNotation {
+	var notations []SigNotation
+	for ; n != nil; n = n.next {
+		notation := SigNotation{
+			Value:         C.GoStringN(n.value, n.value_len),
+			HumanReadable: n.flags&C.GPGME_SIG_NOTATION_HUMAN_READABLE != 0,
+			Critical:      n.flags&C.GPGME_SIG_NOTATION_CRITICAL != 0,
+		}
+		if n.name != nil {
+			notation.Name = C.GoStringN(n.name, n.name_len)
+		}
+		notations = append(notations, notation)
+	}
+	return notations
+}
@@ -0,0 +1,97 @@
+package gpgme
+
+// #include <stdlib.h>
+// #include <gpgme.h>
+// #include "go_gpgme.h"
+import "C"
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// InitOption configures Init
+type InitOption func(*initConfig)
+
+type initConfig struct {
+	minVersion  string
+	ctype       *string
+	messages    *string
+	globalFlags [][2]string
+}
+
+// WithMinVersion makes Init fail if the GPGME library is older than version,
+// e.g. "1.18.0"
+func WithMinVersion(version string) InitOption {
+	return func(cfg *initConfig) { cfg.minVersion = version }
+}
+
+// WithLocale sets the default LC_CTYPE and LC_MESSAGES locales of new
+// contexts, e.g. "C" for stable engine messages. See Context.SetLocale.
+func WithLocale(ctype, messages string) InitOption {
+	return func(cfg *initConfig) { cfg.ctype, cfg.messages = &ctype, &messages }
+}
+
+// WithGlobalFlag sets a global flag, see SetGlobalFlag
+func WithGlobalFlag(name, value string) InitOption {
+	return func(cfg *initConfig) { cfg.globalFlags = append(cfg.globalFlags, [2]string{name, value}) }
+}
+
+var (
+	initOnce sync.Once
+	initErr  error
+)
+
+// Init configures GPGME and checks its version. It should be called once at
+// program start, before any context is created; only the first call has an
+// effect and later calls return its result.
+//
+// The package already initializes GPGME without a version requirement when
+// it is loaded, so calling Init is optional.
+func Init(opts ...InitOption) error {
+	initOnce.Do(func() {
+		var cfg initConfig
+		for _, opt := range opts {
+			opt(&cfg)
+		}
+		initErr = initGPGME(&cfg)
+	})
+	return initErr
+}
+
+func initGPGME(cfg *initConfig) error {
+	for _, f := range cfg.globalFlags {
+		if err := SetGlobalFlag(f[0], f[1]); err != nil {
+			return err
+		}
+	}
+	if cfg.minVersion != "" {
+		cmin := C.CString(cfg.minVersion)
+		defer C.free(unsafe.Pointer(cmin))
+		if C.gpgme_check_version(cmin) == nil {
+			return fmt.Errorf("GPGME version %s is older than required %s", Version, cfg.minVersion)
+		}
+	}
+	if cfg.ctype != nil || cfg.messages != nil {
+		if err := setLocale(nil, cfg.ctype, cfg.messages); err != nil {
+			return fmt.Errorf("setting locale: %w", err)
+		}
+	}
+	return nil
+}
+
+// setLocale sets the locales of ctx, or the defaults if ctx is nil. Nil
+// values are left unchanged.
+func setLocale(ctx C.gpgme_ctx_t, ctype, messages *string) error {
+	var cctype, cmessages *C.char
+	if ctype != nil {
+		cctype = C.CString(*ctype)
+		defer C.free(unsafe.Pointer(cctype))
+	}
+	if messages != nil {
+		cmessages = C.CString(*messages)
+		defer C.free(unsafe.Pointer(cmessages))
+	}
+	return handleError(C.gogpgme_set_locale(ctx, cctype, cmessages))
+}
@@ -0,0 +1,15 @@
+package gpgme
+
+import "testing"
+
+func TestInit(t *testing.T) {
+	checkError(t, Init(WithMinVersion("1.0.0"), WithLocale("C", "C")))
+	// Only the first call has an effect
+	checkError(t, Init(WithMinVersion("99.0.0")))
+
+	var cfg initConfig
+	WithMinVersion("99.0.0")(&cfg)
+	if err := initGPGME(&cfg); err == nil {
+		t.Error("Expected error for unsatisfiable version")
+	}
+}
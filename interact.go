@@ -0,0 +1,34 @@
+package gpgme
+
+// #include <gpgme.h>
+// #include "go_gpgme.h"
+import "C"
+
+import (
+	"runtime"
+	"runtime/cgo"
+	"unsafe"
+)
+
+//export gogpgme_interactfunc
+func gogpgme_interactfunc(handle unsafe.Pointer, keyword, args *C.char, fd C.int) C.gpgme_error_t {
+	h := *(*cgo.Handle)(handle)
+	ia := h.Value().(*interaction)
+	return ia.respond(C.GoString(keyword), C.GoString(args), fd)
+}
+
+func (c *Context) opInteract(key *Key, flags InteractFlag, handle unsafe.Pointer, out *Data) error {
+	var keyPtr C.gpgme_key_t
+	if key != nil {
+		keyPtr = key.k
+	}
+	var outPtr C.gpgme_data_t
+	if out != nil {
+		outPtr = out.dh
+	}
+	err := handleError(C.gogpgme_op_interact(c.ctx, keyPtr, C.uint(flags), handle, outPtr))
+	runtime.KeepAlive(c)
+	runtime.KeepAlive(key)
+	runtime.KeepAlive(out)
+	return err
+}
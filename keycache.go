@@ -0,0 +1,135 @@
+package gpgme
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// KeyCache keeps the results of key lookups in memory for a limited time, so
+// that frequent lookups of the same keys, e.g. when verifying many
+// signatures, don't each list the keyring. A KeyCache is safe for concurrent
+// use.
+//
+// Keys returned by the cache are shared between callers and must not be
+// released.
+type KeyCache struct {
+	ttl time.Duration
+	now func() time.Time // replaced in tests
+
+	mu     sync.Mutex // serializes use of ctx and the maps
+	ctx    *Context
+	byID   map[keyCacheID]keyCacheEntry
+	byAddr map[keyCacheID]keyCacheEntry
+}
+
+type keyCacheID struct {
+	id     string
+	secret bool
+}
+
+type keyCacheEntry struct {
+	keys    []*Key
+	expires time.Time
+}
+
+// NewKeyCache returns a cache that looks up keys with ctx and keeps them for
+// ttl. The cache takes over ctx; it must not be used elsewhere while the cache
+// is in use.
+func NewKeyCache(ctx *Context, ttl time.Duration) *KeyCache {
+	return &KeyCache{
+		ttl:    ttl,
+		now:    time.Now,
+		ctx:    ctx,
+		byID:   make(map[keyCacheID]keyCacheEntry),
+		byAddr: make(map[keyCacheID]keyCacheEntry),
+	}
+}
+
+// GetKey returns the key with the given fingerprint or key ID, as
+// Context.GetKey, from the cache if possible. Failed lookups are not cached.
+func (kc *KeyCache) GetKey(fingerprint string, secret bool) (*Key, error) {
+	id := keyCacheID{id: strings.ToUpper(fingerprint), secret: secret}
+
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+	if e, ok := kc.byID[id]; ok && kc.now().Before(e.expires) {
+		return e.keys[0], nil
+	}
+	key, err := kc.ctx.GetKey(fingerprint, secret)
+	if err != nil {
+		return nil, err
+	}
+	kc.byID[id] = keyCacheEntry{keys: []*Key{key}, expires: kc.now().Add(kc.ttl)}
+	return key, nil
+}
+
+// FindKeys returns the keys with a user ID for the email address addr, from
+// the cache if possible. An empty result is cached like any other.
+func (kc *KeyCache) FindKeys(addr string, secretOnly bool) ([]*Key, error) {
+	addr = strings.ToLower(addr)
+	id := keyCacheID{id: addr, secret: secretOnly}
+
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+	if e, ok := kc.byAddr[id]; ok && kc.now().Before(e.expires) {
+		return e.keys, nil
+	}
+	if err := kc.ctx.KeyListStart("<"+addr+">", secretOnly); err != nil {
+		return nil, err
+	}
+	var keys []*Key
+	for kc.ctx.KeyListNext() {
+		keys = append(keys, kc.ctx.Key)
+	}
+	if kc.ctx.KeyError != nil {
+		_ = kc.ctx.KeyListEnd()
+		return nil, kc.ctx.KeyError
+	}
+	if err := kc.ctx.KeyListEnd(); err != nil {
+		return nil, err
+	}
+	kc.byAddr[id] = keyCacheEntry{keys: keys, expires: kc.now().Add(kc.ttl)}
+	return keys, nil
+}
+
+// Invalidate removes the key with the given fingerprint or key ID, and any
+// email lookups that returned it, from the cache. It should be called after
+// the key is changed in the keyring.
+func (kc *KeyCache) Invalidate(fingerprint string) {
+	fingerprint = strings.ToUpper(fingerprint)
+
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+	for id, e := range kc.byID {
+		if id.id == fingerprint || e.matches(fingerprint) {
+			delete(kc.byID, id)
+		}
+	}
+	for id, e := range kc.byAddr {
+		if e.matches(fingerprint) {
+			delete(kc.byAddr, id)
+		}
+	}
+}
+
+// Purge removes all keys from the cache
+func (kc *KeyCache) Purge() {
+	kc.mu.Lock()
+	defer kc.mu.Unlock()
+	kc.byID = make(map[keyCacheID]keyCacheEntry)
+	kc.byAddr = make(map[keyCacheID]keyCacheEntry)
+}
+
+// matches reports whether any of the keys has a subkey with the given
+// fingerprint or key ID.
+func (e keyCacheEntry) matches(fingerprint string) bool {
+	for _, k := range e.keys {
+		for sk := k.SubKeys(); sk != nil; sk = sk.Next() {
+			if sk.Fingerprint() == fingerprint || sk.KeyID() == fingerprint {
+				return true
+			}
+		}
+	}
+	return false
+}
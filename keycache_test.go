@@ -0,0 +1,47 @@
+package gpgme
+
+import (
+	"testing"
+	"time"
+)
+
+func TestKeyCache(t *testing.T) {
+	ctx, err := New()
+	checkError(t, err)
+	kc := NewKeyCache(ctx, time.Minute)
+	now := time.Now()
+	kc.now = func() time.Time { return now }
+
+	const fpr = "44B646DC347C31E867FF4F450327FFB0229F6136"
+	key, err := kc.GetKey(fpr, false)
+	checkError(t, err)
+	cached, err := kc.GetKey(fpr, false)
+	checkError(t, err)
+	if cached != key {
+		t.Error("Expected cached key")
+	}
+
+	now = now.Add(2 * time.Minute)
+	fresh, err := kc.GetKey(fpr, false)
+	checkError(t, err)
+	if fresh == key {
+		t.Error("Expected expired entry to be looked up again")
+	}
+
+	keys, err := kc.FindKeys("Test@Example.com", false)
+	checkError(t, err)
+	if len(keys) != 1 || keys[0].SubKeys().Fingerprint() != fpr {
+		t.Fatalf("Unexpected keys for email: %v", keys)
+	}
+	kc.Invalidate(fpr)
+	if len(kc.byID) != 0 || len(kc.byAddr) != 0 {
+		t.Error("Expected invalidated entries to be removed")
+	}
+
+	if _, err := kc.GetKey("0000000000000000", false); err == nil {
+		t.Error("Expected error for unknown key")
+	}
+	if len(kc.byID) != 0 {
+		t.Error("Expected failed lookup not to be cached")
+	}
+}
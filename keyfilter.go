@@ -0,0 +1,48 @@
+package gpgme
+
+// KeyFilter reports whether a key should be included in a listing
+type KeyFilter func(*Key) bool
+
+// OnlyCanEncrypt accepts keys that can be used for encryption
+func OnlyCanEncrypt(k *Key) bool {
+	return k.CanEncrypt()
+}
+
+// OnlyCanSign accepts keys that can be used for signing
+func OnlyCanSign(k *Key) bool {
+	return k.CanSign()
+}
+
+// OnlySecret accepts keys with a secret part. The key listing must be for
+// secret keys for this to be reliable.
+func OnlySecret(k *Key) bool {
+	return k.Secret()
+}
+
+// NotExpired accepts keys whose primary key has not expired
+func NotExpired(k *Key) bool {
+	return !k.Expired()
+}
+
+// ListKeysFiltered lists the keys matching pattern, as KeyListStart, and
+// returns those accepted by all of filters.
+func (c *Context) ListKeysFiltered(pattern string, secretOnly bool, filters ...KeyFilter) ([]*Key, error) {
+	var keys []*Key
+	if err := c.KeyListStart(pattern, secretOnly); err != nil {
+		return keys, err
+	}
+	defer func() { _ = c.KeyListEnd() }()
+next:
+	for c.KeyListNext() {
+		for _, f := range filters {
+			if !f(c.Key) {
+				continue next
+			}
+		}
+		keys = append(keys, c.Key)
+	}
+	if c.KeyError != nil {
+		return keys, c.KeyError
+	}
+	return keys, nil
+}
@@ -0,0 +1,24 @@
+package gpgme
+
+import "testing"
+
+func TestContext_ListKeysFiltered(t *testing.T) {
+	ctx, err := New()
+	checkError(t, err)
+
+	keys, err := ctx.ListKeysFiltered("test@example.com", false, OnlyCanEncrypt, NotExpired)
+	checkError(t, err)
+	if len(keys) != 1 {
+		t.Fatalf("Expected 1 key, got %d", len(keys))
+	}
+	if fpr := keys[0].SubKeys().Fingerprint(); fpr != "44B646DC347C31E867FF4F450327FFB0229F6136" {
+		t.Errorf("Unexpected key %s", fpr)
+	}
+
+	reject := func(*Key) bool { return false }
+	keys, err = ctx.ListKeysFiltered("", false, OnlyCanSign, reject)
+	checkError(t, err)
+	if len(keys) != 0 {
+		t.Errorf("Expected no keys, got %d", len(keys))
+	}
+}
@@ -0,0 +1,104 @@
+package gpgme
+
+import (
+	"io"
+	"sync"
+)
+
+// KeyRing is a GnuPG home directory with a Context of its own. It lets an
+// application work with several keyrings side by side without changing the
+// engine info of the process. A KeyRing is safe for concurrent use.
+type KeyRing struct {
+	homeDir string
+
+	mu  sync.Mutex // serializes use of ctx
+	ctx *Context
+}
+
+// OpenKeyRing opens the OpenPGP keyring in homeDir. An empty homeDir selects
+// the engine default, usually ~/.gnupg.
+func OpenKeyRing(homeDir string) (*KeyRing, error) {
+	ctx, err := New()
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.SetEngineInfo(ProtocolOpenPGP, "", homeDir); err != nil {
+		ctx.Release()
+		return nil, err
+	}
+	return &KeyRing{homeDir: homeDir, ctx: ctx}, nil
+}
+
+// HomeDir returns the home directory the keyring was opened with
+func (r *KeyRing) HomeDir() string {
+	return r.homeDir
+}
+
+// List returns the keys matching pattern; an empty pattern matches all keys
+func (r *KeyRing) List(pattern string, secretOnly bool) ([]*Key, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var keys []*Key
+	if err := r.ctx.KeyListStart(pattern, secretOnly); err != nil {
+		return keys, err
+	}
+	defer func() { _ = r.ctx.KeyListEnd() }()
+	for r.ctx.KeyListNext() {
+		keys = append(keys, r.ctx.Key)
+	}
+	if r.ctx.KeyError != nil {
+		return keys, r.ctx.KeyError
+	}
+	return keys, nil
+}
+
+// Get returns the key with the given fingerprint or key ID
+func (r *KeyRing) Get(fingerprint string, secret bool) (*Key, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ctx.GetKey(fingerprint, secret)
+}
+
+// Import reads keys from rd into the keyring
+func (r *KeyRing) Import(rd io.Reader) (*ImportResult, error) {
+	data, err := NewDataReader(rd)
+	if err != nil {
+		return nil, err
+	}
+	defer data.Close()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ctx.Import(data)
+}
+
+// Export writes the public keys matching any of patterns to w, or all keys if
+// no patterns are given. The output is ASCII armored if armor is set.
+func (r *KeyRing) Export(w io.Writer, armor bool, patterns ...string) error {
+	data, err := NewDataWriter(w)
+	if err != nil {
+		return err
+	}
+	defer data.Close()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	prev := r.ctx.Armor()
+	r.ctx.SetArmor(armor)
+	defer r.ctx.SetArmor(prev)
+	return r.ctx.ExportExt(patterns, 0, data)
+}
+
+// Delete removes key from the keyring
+func (r *KeyRing) Delete(key *Key, flags DeleteFlag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.ctx.DeleteKey(key, flags)
+}
+
+// Close releases the context of the keyring. Keys returned by the keyring
+// remain valid.
+func (r *KeyRing) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ctx.Release()
+	return nil
+}
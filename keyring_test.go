@@ -0,0 +1,60 @@
+package gpgme
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestKeyRing(t *testing.T) {
+	ensureVersion(t, "2.", "separate keyrings require GPG v2.x")
+
+	homeDir, err := ioutil.TempDir("", "gpgme-keyring")
+	checkError(t, err)
+	t.Cleanup(func() {
+		_ = exec.Command("gpgconf", "--homedir", homeDir, "--kill", "gpg-agent").Run()
+		os.RemoveAll(homeDir)
+	})
+
+	ring, err := OpenKeyRing(homeDir)
+	checkError(t, err)
+	defer ring.Close()
+
+	keys, err := ring.List("", false)
+	checkError(t, err)
+	if len(keys) != 0 {
+		t.Fatalf("Expected empty keyring, got %d keys", len(keys))
+	}
+
+	f, err := os.Open("testdata/pubkeys.gpg")
+	checkError(t, err)
+	defer f.Close()
+	res, err := ring.Import(f)
+	checkError(t, err)
+	if res.Imported == 0 {
+		t.Fatal("Expected imported keys")
+	}
+
+	keys, err = ring.List("", false)
+	checkError(t, err)
+	if len(keys) != res.Imported {
+		t.Errorf("Expected %d keys, got %d", res.Imported, len(keys))
+	}
+
+	fpr := keys[0].SubKeys().Fingerprint()
+	key, err := ring.Get(fpr, false)
+	checkError(t, err)
+
+	var buf bytes.Buffer
+	checkError(t, ring.Export(&buf, true, fpr))
+	if !bytes.HasPrefix(buf.Bytes(), []byte("-----BEGIN PGP PUBLIC KEY BLOCK-----")) {
+		t.Error("Expected armored export")
+	}
+
+	checkError(t, ring.Delete(key, DeleteForce))
+	if _, err := ring.Get(fpr, false); err == nil {
+		t.Error("Expected deleted key to be gone")
+	}
+}
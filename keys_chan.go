@@ -0,0 +1,37 @@
+package gpgme
+
+import "context"
+
+// KeyListChan lists the keys matching pattern, as KeyListStart, in the
+// background and sends them on the returned key channel as they arrive. The
+// key channel is closed when the listing ends, after which the error channel
+// receives the error of the listing, or nil.
+//
+// Cancelling ctx stops the listing before the next key is sent; the error is
+// then ctx.Err(). The Context must not be used for anything else until the
+// key channel is closed.
+func (c *Context) KeyListChan(ctx context.Context, pattern string, secretOnly bool) (<-chan *Key, <-chan error) {
+	keys := make(chan *Key)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- c.keyListChan(ctx, pattern, secretOnly, keys)
+		close(errc)
+	}()
+	return keys, errc
+}
+
+func (c *Context) keyListChan(ctx context.Context, pattern string, secretOnly bool, keys chan<- *Key) error {
+	defer close(keys)
+	if err := c.KeyListStart(pattern, secretOnly); err != nil {
+		return err
+	}
+	defer func() { _ = c.KeyListEnd() }()
+	for c.KeyListNext() {
+		select {
+		case keys <- c.Key:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return c.KeyError
+}
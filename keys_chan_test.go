@@ -0,0 +1,32 @@
+package gpgme
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestContext_KeyListChan(t *testing.T) {
+	ctx, err := New()
+	checkError(t, err)
+
+	keys, errc := ctx.KeyListChan(context.Background(), "", false)
+	var n int
+	for range keys {
+		n++
+	}
+	checkError(t, <-errc)
+	if n == 0 {
+		t.Fatal("Expected keys")
+	}
+
+	cctx, cancel := context.WithCancel(context.Background())
+	keys, errc = ctx.KeyListChan(cctx, "", false)
+	<-keys
+	cancel()
+	for range keys {
+	}
+	if err := <-errc; err != nil && !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected cancellation, got %v", err)
+	}
+}
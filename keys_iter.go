@@ -0,0 +1,36 @@
+//go:build go1.23
+// +build go1.23
+
+package gpgme
+
+import "iter"
+
+// Keys returns an iterator over the keys matching pattern, as KeyListStart,
+// for use with range:
+//
+//	for key, err := range ctx.Keys("", false) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// An error ends the iteration. The listing is ended when the loop exits,
+// including on an early break.
+func (c *Context) Keys(pattern string, secretOnly bool) iter.Seq2[*Key, error] {
+	return func(yield func(*Key, error) bool) {
+		if err := c.KeyListStart(pattern, secretOnly); err != nil {
+			yield(nil, err)
+			return
+		}
+		defer func() { _ = c.KeyListEnd() }()
+		for c.KeyListNext() {
+			if !yield(c.Key, nil) {
+				return
+			}
+		}
+		if c.KeyError != nil {
+			yield(nil, c.KeyError)
+		}
+	}
+}
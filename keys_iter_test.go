@@ -0,0 +1,30 @@
+//go:build go1.23
+// +build go1.23
+
+package gpgme
+
+import "testing"
+
+func TestContext_Keys(t *testing.T) {
+	ctx, err := New()
+	checkError(t, err)
+
+	var n int
+	for key, err := range ctx.Keys("", false) {
+		checkError(t, err)
+		if key == nil {
+			t.Fatal("Expected key")
+		}
+		n++
+	}
+	if n == 0 {
+		t.Fatal("Expected keys")
+	}
+
+	for range ctx.Keys("", false) {
+		break
+	}
+	// The listing must have been ended by the break.
+	checkError(t, ctx.KeyListStart("test@example.com", false))
+	checkError(t, ctx.KeyListEnd())
+}
@@ -0,0 +1,14 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+// +build linux darwin dragonfly freebsd netbsd openbsd
+
+package gpgme
+
+import "syscall"
+
+func mlock(b []byte) error {
+	return syscall.Mlock(b)
+}
+
+func munlock(b []byte) error {
+	return syscall.Munlock(b)
+}
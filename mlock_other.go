@@ -0,0 +1,16 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+// +build !linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd
+
+package gpgme
+
+import "errors"
+
+var errMlockUnsupported = errors.New("memory locking not supported")
+
+func mlock(b []byte) error {
+	return errMlockUnsupported
+}
+
+func munlock(b []byte) error {
+	return errMlockUnsupported
+}
@@ -0,0 +1,258 @@
+package gpgme
+
+// EncryptOptions configures EncryptWithOptions. Zero fields keep the
+// settings of the context.
+type EncryptOptions struct {
+	// Recipients are the keys to encrypt for. With EncryptSymmetric and no
+	// recipients the message is only encrypted with a passphrase.
+	Recipients []*Key
+	Flags      EncryptFlag
+	// Signers, if not empty, also sign the message, as EncryptSign.
+	Signers []*Key
+	// Armor and TextMode, if not nil, override the settings of the context
+	// for this operation.
+	Armor    *bool
+	TextMode *bool
+	// Notations, if not empty, replace the notations of the context for the
+	// signatures made with Signers.
+	Notations []SigNotation
+	// Sender, if not empty, is the mail address of the sender, see
+	// Context.SetSender.
+	Sender string
+	// IncludeKeyBlock includes the signer's public key in the signatures,
+	// see Context.SetIncludeKeyBlock.
+	IncludeKeyBlock bool
+}
+
+// SignOptions configures SignWithOptions. Zero fields keep the settings of
+// the context.
+type SignOptions struct {
+	// Signers are the signing keys; empty signs with the default key.
+	Signers []*Key
+	Mode    SigMode
+	// Armor and TextMode, if not nil, override the settings of the context
+	// for this operation.
+	Armor    *bool
+	TextMode *bool
+	// Notations, if not empty, replace the notations of the context for the
+	// signatures.
+	Notations []SigNotation
+	// Sender, if not empty, is the mail address of the sender, see
+	// Context.SetSender.
+	Sender string
+	// IncludeKeyBlock includes the signer's public key in the signatures,
+	// see Context.SetIncludeKeyBlock.
+	IncludeKeyBlock bool
+}
+
+// DecryptOptions configures DecryptWithOptions
+type DecryptOptions struct {
+	Flags DecryptFlag
+	// MaxPlaintextBytes overrides the limit of the context for this
+	// operation, see SetMaxPlaintextBytes.
+	MaxPlaintextBytes int64
+	// Signers, if not empty, requires a valid signature by one of the given
+	// primary key or signing subkey fingerprints. It implies DecryptVerify.
+	Signers []Fingerprint
+	// NoSymkeyCache keeps the passphrase of a symmetrically encrypted
+	// message from being cached, see Context.SetNoSymkeyCache.
+	NoSymkeyCache bool
+}
+
+// VerifyOptions configures VerifyWithOptions
+type VerifyOptions struct {
+	// Sender is the mail address of the expected signer, see
+	// Context.SetSender.
+	Sender string
+	// Signers, if not empty, requires a valid signature by one of the given
+	// primary key or signing subkey fingerprints.
+	Signers []Fingerprint
+	// AutoKeyRetrieve fetches unknown signer keys during verification, see
+	// Context.SetAutoKeyRetrieve, and sets them as Signature.Key.
+	AutoKeyRetrieve bool
+	// AutoKeyImport imports signer keys included in the signatures, see
+	// Context.SetAutoKeyImport.
+	AutoKeyImport bool
+}
+
+// EncryptWithOptions encrypts plaintext into ciphertext as configured by
+// opts. The settings of the context changed by opts are restored afterwards.
+// The results are available from EncryptResult and, when signing, SignResult.
+func (c *Context) EncryptWithOptions(plaintext, ciphertext *Data, opts EncryptOptions) error {
+	restore, err := c.applyOptions(opts.Armor, opts.TextMode, opts.Sender, opts.Notations)
+	if err != nil {
+		return err
+	}
+	defer restore()
+	if opts.IncludeKeyBlock {
+		restoreFlag, err := c.withBoolFlag("include-key-block", true)
+		if err != nil {
+			return err
+		}
+		defer restoreFlag()
+	}
+	if len(opts.Signers) > 0 {
+		_, _, err = c.EncryptSign(opts.Recipients, opts.Signers, opts.Flags, plaintext, ciphertext)
+		return err
+	}
+	return c.Encrypt(opts.Recipients, opts.Flags, plaintext, ciphertext)
+}
+
+// SignWithOptions signs plain into sig as configured by opts. The settings
+// of the context changed by opts are restored afterwards.
+func (c *Context) SignWithOptions(plain, sig *Data, opts SignOptions) error {
+	restore, err := c.applyOptions(opts.Armor, opts.TextMode, opts.Sender, opts.Notations)
+	if err != nil {
+		return err
+	}
+	defer restore()
+	if opts.IncludeKeyBlock {
+		restoreFlag, err := c.withBoolFlag("include-key-block", true)
+		if err != nil {
+			return err
+		}
+		defer restoreFlag()
+	}
+	return c.Sign(opts.Signers, plain, sig, opts.Mode)
+}
+
+// DecryptWithOptions decrypts ciphertext into plaintext as configured by
+// opts, see DecryptExt. If opts.Signers is set and no signature satisfies it
+// the error is ErrNoRequiredSignature.
+func (c *Context) DecryptWithOptions(ciphertext, plaintext *Data, opts DecryptOptions) (*DecryptResult, []Signature, error) {
+	if opts.MaxPlaintextBytes != 0 {
+		max := c.MaxPlaintextBytes()
+		c.SetMaxPlaintextBytes(opts.MaxPlaintextBytes)
+		defer c.SetMaxPlaintextBytes(max)
+	}
+	if opts.NoSymkeyCache {
+		restore, err := c.withBoolFlag("no-symkey-cache", true)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer restore()
+	}
+	flags := opts.Flags
+	if len(opts.Signers) > 0 {
+		flags |= DecryptVerify
+	}
+	res, sigs, err := c.DecryptExt(flags, ciphertext, plaintext)
+	if err != nil {
+		return res, sigs, err
+	}
+	if len(opts.Signers) > 0 && !c.hasSignatureBy(sigs, opts.Signers) {
+		return res, sigs, ErrNoRequiredSignature
+	}
+	return res, sigs, nil
+}
+
+// VerifyWithOptions verifies sig as Verify, as configured by opts. If
+// opts.Signers is set and no signature satisfies it the error is
+// ErrNoRequiredSignature.
+func (c *Context) VerifyWithOptions(sig, signedText, plain *Data, opts VerifyOptions) (string, []Signature, error) {
+	if opts.Sender != "" {
+		prev := c.Sender()
+		if err := c.SetSender(opts.Sender); err != nil {
+			return "", nil, err
+		}
+		defer func() { _ = c.SetSender(prev) }()
+	}
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{"auto-key-retrieve", opts.AutoKeyRetrieve},
+		{"auto-key-import", opts.AutoKeyImport},
+	} {
+		if !f.set {
+			continue
+		}
+		restore, err := c.withBoolFlag(f.name, true)
+		if err != nil {
+			return "", nil, err
+		}
+		defer restore()
+	}
+	fileName, sigs, err := c.Verify(sig, signedText, plain)
+	if err != nil {
+		return fileName, sigs, err
+	}
+	if opts.AutoKeyRetrieve {
+		if err := c.ResolveSignerKeys(sigs); err != nil {
+			return fileName, sigs, err
+		}
+	}
+	if len(opts.Signers) > 0 && !c.hasSignatureBy(sigs, opts.Signers) {
+		return fileName, sigs, ErrNoRequiredSignature
+	}
+	return fileName, sigs, nil
+}
+
+// applyOptions applies the settings shared by the encrypt and sign options
+// that are set and returns a function restoring the previous settings.
+func (c *Context) applyOptions(armor, textMode *bool, sender string, notations []SigNotation) (func(), error) {
+	prevArmor, prevTextMode := c.Armor(), c.TextMode()
+	prevSender := c.Sender()
+	var prevNotations []SigNotation
+	if len(notations) > 0 {
+		prevNotations = c.SigNotations()
+	}
+	restore := func() {
+		c.SetArmor(prevArmor)
+		c.SetTextMode(prevTextMode)
+		if sender != "" {
+			_ = c.SetSender(prevSender)
+		}
+		if len(notations) > 0 {
+			c.SigNotationClear()
+			_ = c.addSigNotations(prevNotations)
+		}
+	}
+
+	if armor != nil {
+		c.SetArmor(*armor)
+	}
+	if textMode != nil {
+		c.SetTextMode(*textMode)
+	}
+	if sender != "" {
+		if err := c.SetSender(sender); err != nil {
+			restore()
+			return nil, err
+		}
+	}
+	if len(notations) > 0 {
+		c.SigNotationClear()
+		if err := c.addSigNotations(notations); err != nil {
+			restore()
+			return nil, err
+		}
+	}
+	return restore, nil
+}
+
+// withBoolFlag sets the boolean context flag name and returns a function
+// restoring its previous value
+func (c *Context) withBoolFlag(name string, yes bool) (func(), error) {
+	prev := c.boolFlag(name)
+	if err := c.setBoolFlag(name, yes); err != nil {
+		return nil, err
+	}
+	return func() { _ = c.setBoolFlag(name, prev) }, nil
+}
+
+func (c *Context) addSigNotations(notations []SigNotation) error {
+	for _, n := range notations {
+		var flags SigNotationFlag
+		if n.HumanReadable {
+			flags |= SigNotationHumanReadable
+		}
+		if n.Critical {
+			flags |= SigNotationCritical
+		}
+		if err := c.SigNotationAdd(n.Name, n.Value, flags); err != nil {
+			return err
+		}
+	}
+	return nil
+}
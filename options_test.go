@@ -0,0 +1,139 @@
+package gpgme
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestContext_SignWithOptions(t *testing.T) {
+	ctx := tempHomeContext(t)
+	key := newTestKey(t, ctx, "Options <options@example.com>", "ed25519", CreateSign|CreateNoPassword)
+
+	plain, err := NewDataBytes([]byte(testData))
+	checkError(t, err)
+	var buf bytes.Buffer
+	signed, err := NewDataWriter(&buf)
+	checkError(t, err)
+	armor := true
+	checkError(t, ctx.SignWithOptions(plain, signed, SignOptions{
+		Signers:   []*Key{key},
+		Mode:      SigModeNormal,
+		Armor:     &armor,
+		Notations: []SigNotation{{Name: "build@example.com", Value: "42"}},
+	}))
+	if !bytes.HasPrefix(buf.Bytes(), []byte("-----BEGIN PGP MESSAGE-----")) {
+		t.Errorf("Expected armored message, got %q", buf.Bytes())
+	}
+	if ctx.Armor() || len(ctx.SigNotations()) != 0 {
+		t.Error("Expected context settings to be restored")
+	}
+
+	for _, tt := range []struct {
+		signer Fingerprint
+		err    error
+	}{
+		{Fingerprint(key.SubKeys().Fingerprint()), nil},
+		{"0000000000000000000000000000000000000000", ErrNoRequiredSignature},
+	} {
+		signed, err := NewDataBytes(buf.Bytes())
+		checkError(t, err)
+		_, sigs, err := ctx.VerifyWithOptions(signed, nil, nil, VerifyOptions{Signers: []Fingerprint{tt.signer}})
+		if err != tt.err {
+			t.Errorf("signer %s: expected %v, got %v", tt.signer, tt.err, err)
+		}
+		if len(sigs) != 1 || len(sigs[0].Notations) != 1 {
+			t.Errorf("signer %s: unexpected signatures %+v", tt.signer, sigs)
+		}
+	}
+}
+
+func TestContext_EncryptWithOptionsKeepsContext(t *testing.T) {
+	ctx, err := New()
+	checkError(t, err)
+	key, err := ctx.GetKey("test@example.com", false)
+	checkError(t, err)
+	ctx.SetArmor(true)
+	checkError(t, ctx.SetSender("test@example.com"))
+
+	plain, err := NewDataBytes([]byte(testData))
+	checkError(t, err)
+	var buf bytes.Buffer
+	cipher, err := NewDataWriter(&buf)
+	checkError(t, err)
+	checkError(t, ctx.EncryptWithOptions(plain, cipher, EncryptOptions{
+		Recipients: []*Key{key},
+		Flags:      EncryptAlwaysTrust,
+	}))
+	if !bytes.HasPrefix(buf.Bytes(), []byte("-----BEGIN PGP MESSAGE-----")) {
+		t.Errorf("Expected armored message, got %q", buf.Bytes())
+	}
+	if !ctx.Armor() || ctx.Sender() != "test@example.com" {
+		t.Error("Expected context settings to be kept")
+	}
+}
+
+func TestContext_SignWithOptionsTextMode(t *testing.T) {
+	ctx := tempHomeContext(t)
+	key := newTestKey(t, ctx, "Text <text@example.com>", "ed25519", CreateSign|CreateNoPassword)
+
+	plain, err := NewDataBytes([]byte("line one\nline two\n"))
+	checkError(t, err)
+	var buf bytes.Buffer
+	sig, err := NewDataWriter(&buf)
+	checkError(t, err)
+	textMode := true
+	checkError(t, ctx.SignWithOptions(plain, sig, SignOptions{
+		Signers:  []*Key{key},
+		Mode:     SigModeDetach,
+		TextMode: &textMode,
+	}))
+	sigs := ctx.SignResult().Signatures
+	if len(sigs) != 1 || sigs[0].Class != 0x01 {
+		t.Fatalf("Expected a canonical text signature, got %+v", sigs)
+	}
+
+	// The signature verifies against the text with CR/LF line endings.
+	sigData, err := NewDataBytes(buf.Bytes())
+	checkError(t, err)
+	signed, err := NewDataBytes([]byte("line one\r\nline two\r\n"))
+	checkError(t, err)
+	_, vsigs, err := ctx.Verify(sigData, signed, nil)
+	checkError(t, err)
+	if len(vsigs) != 1 || vsigs[0].Status != nil {
+		t.Errorf("Expected good signature, got %+v", vsigs)
+	}
+}
+
+func TestContext_IncludeKeyBlock(t *testing.T) {
+	signer := tempHomeContext(t)
+	if err := signer.SetIncludeKeyBlock(false); err != nil {
+		t.Skip("include-key-block not supported:", err)
+	}
+	key := newTestKey(t, signer, "Keyblock <keyblock@example.com>", "ed25519", CreateSign|CreateNoPassword)
+
+	plain, err := NewDataBytes([]byte(testData))
+	checkError(t, err)
+	var buf bytes.Buffer
+	signed, err := NewDataWriter(&buf)
+	checkError(t, err)
+	checkError(t, signer.SignWithOptions(plain, signed, SignOptions{
+		Signers:         []*Key{key},
+		Mode:            SigModeNormal,
+		IncludeKeyBlock: true,
+	}))
+	if signer.IncludeKeyBlock() {
+		t.Error("Expected include-key-block to be restored")
+	}
+
+	verifier := tempHomeContext(t)
+	signedData, err := NewDataBytes(buf.Bytes())
+	checkError(t, err)
+	_, sigs, err := verifier.VerifyWithOptions(signedData, nil, nil, VerifyOptions{AutoKeyImport: true})
+	checkError(t, err)
+	if len(sigs) != 1 || sigs[0].Summary&SigSumKeyMissing != 0 {
+		t.Errorf("Expected signer key to be imported, got %+v", sigs)
+	}
+	if _, err := verifier.GetKey(key.SubKeys().Fingerprint(), false); err != nil {
+		t.Errorf("Expected imported key: %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package gpgme
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// VerifyPolicy describes which signatures an application accepts. The zero
+// value accepts any good signature that is not flagged red, expired or made
+// by an expired or revoked key.
+type VerifyPolicy struct {
+	// Signers are the accepted signer fingerprints; empty accepts any signer.
+	// A primary key fingerprint only matches a signature made by a subkey if
+	// the signing key is available in Signature.Key.
+	Signers []Fingerprint
+	// MinValidity is the lowest accepted validity of the signature; the zero
+	// value, ValidityUnknown, accepts any validity.
+	MinValidity Validity
+	// HashAlgos are the accepted hash algorithms; empty accepts any.
+	HashAlgos []HashAlgo
+	// NotBefore and NotAfter bound the signature creation time if not zero.
+	NotBefore time.Time
+	NotAfter  time.Time
+	// AllowExpired accepts expired signatures and signatures by expired
+	// keys, e.g. to check old releases.
+	AllowExpired bool
+	// AllowRevoked accepts signatures by revoked keys.
+	AllowRevoked bool
+}
+
+// PolicyError is returned by VerifyPolicy.Evaluate when no signature is
+// accepted. Reasons holds why each signature was rejected.
+type PolicyError struct {
+	Reasons []string
+}
+
+func (e *PolicyError) Error() string {
+	if len(e.Reasons) == 0 {
+		return "no signatures"
+	}
+	return "signature rejected by policy: " + strings.Join(e.Reasons, "; ")
+}
+
+// Evaluate returns the first of sigs accepted by the policy, or a
+// *PolicyError if there is none.
+func (p *VerifyPolicy) Evaluate(sigs []Signature) (*Signature, error) {
+	perr := &PolicyError{}
+	for i := range sigs {
+		reason := p.check(&sigs[i])
+		if reason == "" {
+			return &sigs[i], nil
+		}
+		perr.Reasons = append(perr.Reasons, fmt.Sprintf("%s: %s", sigs[i].Fingerprint, reason))
+	}
+	return nil, perr
+}
+
+// check returns why sig is rejected, or an empty string if it is accepted.
+func (p *VerifyPolicy) check(sig *Signature) string {
+	if sig.Status != nil {
+		var e Error
+		if !errors.As(sig.Status, &e) {
+			return sig.Status.Error()
+		}
+		switch code := e.Code(); {
+		case (code == ErrorSigExpired || code == ErrorKeyExpired) && p.AllowExpired:
+		case code == ErrorCertRevoked && p.AllowRevoked:
+		default:
+			return sig.Status.Error()
+		}
+	}
+	if sig.Summary&SigSumRed != 0 {
+		return "signature is flagged red"
+	}
+	if !p.AllowExpired && sig.Summary&(SigSumSigExpired|SigSumKeyExpired) != 0 {
+		return "signature or key expired"
+	}
+	if !p.AllowRevoked && sig.Summary&SigSumKeyRevoked != 0 {
+		return "key revoked"
+	}
+	if p.MinValidity != ValidityUnknown && !sig.Validity.AtLeast(p.MinValidity) {
+		return fmt.Sprintf("validity %s below %s", sig.Validity, p.MinValidity)
+	}
+	if len(p.Signers) > 0 && !p.signerAllowed(sig) {
+		return "signer not allowed"
+	}
+	if len(p.HashAlgos) > 0 && !p.hashAllowed(sig.HashAlgo) {
+		return fmt.Sprintf("hash algorithm %s not allowed", sig.HashAlgo)
+	}
+	if !p.NotBefore.IsZero() && sig.Timestamp.Before(p.NotBefore) {
+		return "signature made before " + p.NotBefore.String()
+	}
+	if !p.NotAfter.IsZero() && sig.Timestamp.After(p.NotAfter) {
+		return "signature made after " + p.NotAfter.String()
+	}
+	return ""
+}
+
+func (p *VerifyPolicy) signerAllowed(sig *Signature) bool {
+	fprs := []string{sig.Fingerprint}
+	if sig.Key != nil {
+		fprs = append(fprs, sig.Key.SubKeys().Fingerprint())
+	}
+	return matchesSigner(fprs, p.Signers)
+}
+
+// matchesSigner reports whether any of fprs is one of signers
+func matchesSigner(fprs []string, signers []Fingerprint) bool {
+	for _, s := range fprs {
+		fpr, err := ParseFingerprint(s)
+		if err != nil {
+			continue
+		}
+		for _, signer := range signers {
+			if fpr.Equal(signer) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (p *VerifyPolicy) hashAllowed(h HashAlgo) bool {
+	for _, allowed := range p.HashAlgos {
+		if h == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package gpgme
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVerifyPolicy_Evaluate(t *testing.T) {
+	signer := Fingerprint("44B646DC347C31E867FF4F450327FFB0229F6136")
+	now := time.Now()
+	good := Signature{
+		Summary:     SigSumValid | SigSumGreen,
+		Fingerprint: string(signer),
+		Timestamp:   now,
+		Validity:    ValidityFull,
+		HashAlgo:    HashSHA256,
+	}
+	expired := good
+	expired.Summary = SigSumKeyExpired
+	revoked := good
+	revoked.Summary = SigSumKeyRevoked
+
+	for _, tt := range []struct {
+		name   string
+		policy VerifyPolicy
+		sig    Signature
+		ok     bool
+	}{
+		{"zero policy", VerifyPolicy{}, good, true},
+		{"signer", VerifyPolicy{Signers: []Fingerprint{signer}}, good, true},
+		{"other signer", VerifyPolicy{Signers: []Fingerprint{"0000000000000000000000000000000000000000"}}, good, false},
+		{"validity", VerifyPolicy{MinValidity: ValidityUltimate}, good, false},
+		{"hash", VerifyPolicy{HashAlgos: []HashAlgo{HashSHA512}}, good, false},
+		{"not before", VerifyPolicy{NotBefore: now.Add(time.Hour)}, good, false},
+		{"not after", VerifyPolicy{NotAfter: now.Add(-time.Hour)}, good, false},
+		{"expired rejected", VerifyPolicy{}, expired, false},
+		{"expired allowed", VerifyPolicy{AllowExpired: true}, expired, true},
+		{"revoked rejected", VerifyPolicy{}, revoked, false},
+		{"revoked allowed", VerifyPolicy{AllowRevoked: true}, revoked, true},
+		{"revoked with expired allowed", VerifyPolicy{AllowExpired: true}, revoked, false},
+	} {
+		sig, err := tt.policy.Evaluate([]Signature{tt.sig})
+		if tt.ok && (err != nil || sig == nil) {
+			t.Errorf("%s: expected signature to be accepted, got %v", tt.name, err)
+		}
+		if !tt.ok {
+			if _, isPolicyErr := err.(*PolicyError); !isPolicyErr {
+				t.Errorf("%s: expected PolicyError, got %v", tt.name, err)
+			}
+		}
+	}
+}
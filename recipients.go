@@ -0,0 +1,118 @@
+package gpgme
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RecipientPolicy controls which keys ResolveRecipients accepts
+type RecipientPolicy struct {
+	// MinValidity is the lowest acceptable validity of the user ID matching
+	// the address. The zero value, ValidityUnknown, accepts any validity.
+	MinValidity Validity
+	// Locate also looks for keys in the Web Key Directory and on keyservers,
+	// as configured by auto-key-locate, see LocateKey.
+	Locate bool
+}
+
+// RecipientError describes why no key could be selected for an address
+type RecipientError struct {
+	Email string
+	Err   error
+}
+
+func (e *RecipientError) Error() string {
+	return fmt.Sprintf("recipient %s: %v", e.Email, e.Err)
+}
+
+func (e *RecipientError) Unwrap() error {
+	return e.Err
+}
+
+// RecipientErrors is returned by ResolveRecipients when one or more addresses
+// could not be resolved
+type RecipientErrors []*RecipientError
+
+func (e RecipientErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ResolveRecipients selects an encryption key for each of emails. A key is
+// acceptable if it can encrypt, is not revoked, expired, disabled or invalid,
+// and has a valid user ID with the address whose validity satisfies policy.
+// Of several acceptable keys the most recently created is selected.
+//
+// The returned keys are in the order of emails. If any address could not be
+// resolved the error is a RecipientErrors and the key for that address is
+// nil.
+func (c *Context) ResolveRecipients(emails []string, policy RecipientPolicy) ([]*Key, error) {
+	if policy.Locate {
+		mode := c.KeyListMode()
+		if err := c.SetKeyListMode(mode | KeyListModeLocate); err != nil {
+			return nil, err
+		}
+		defer func() { _ = c.SetKeyListMode(mode) }()
+	}
+
+	keys := make([]*Key, len(emails))
+	var errs RecipientErrors
+	for i, email := range emails {
+		key, err := c.resolveRecipient(email, policy)
+		if err != nil {
+			errs = append(errs, &RecipientError{Email: email, Err: err})
+			continue
+		}
+		keys[i] = key
+	}
+	if errs != nil {
+		return keys, errs
+	}
+	return keys, nil
+}
+
+func (c *Context) resolveRecipient(email string, policy RecipientPolicy) (*Key, error) {
+	addr := strings.ToLower(email)
+	if err := c.KeyListStart("<"+addr+">", false); err != nil {
+		return nil, err
+	}
+	var best *Key
+	for c.KeyListNext() {
+		k := c.Key
+		if !acceptRecipient(k, addr, policy) {
+			continue
+		}
+		if best == nil || k.SubKeys().Created().After(best.SubKeys().Created()) {
+			best = k
+		}
+	}
+	if c.KeyError != nil {
+		_ = c.KeyListEnd()
+		return nil, c.KeyError
+	}
+	if err := c.KeyListEnd(); err != nil {
+		return nil, err
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no usable key found")
+	}
+	return best, nil
+}
+
+func acceptRecipient(k *Key, addr string, policy RecipientPolicy) bool {
+	if k.Revoked() || k.Expired() || k.Disabled() || k.Invalid() || !k.CanEncrypt() {
+		return false
+	}
+	for u := k.UserIDs(); u != nil; u = u.Next() {
+		if u.Revoked() || u.Invalid() || strings.ToLower(u.Address()) != addr {
+			continue
+		}
+		if policy.MinValidity == ValidityUnknown || u.Validity().AtLeast(policy.MinValidity) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+package gpgme
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestContext_ResolveRecipients(t *testing.T) {
+	ctx, err := New()
+	checkError(t, err)
+
+	keys, err := ctx.ResolveRecipients([]string{"Test@Example.com", "nobody@example.com"}, RecipientPolicy{})
+	var errs RecipientErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected RecipientErrors, got %v", err)
+	}
+	if len(errs) != 1 || errs[0].Email != "nobody@example.com" {
+		t.Errorf("Unexpected errors %v", errs)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("Expected a key slot per address, got %d", len(keys))
+	}
+	if keys[0] == nil || keys[0].SubKeys().Fingerprint() != "44B646DC347C31E867FF4F450327FFB0229F6136" {
+		t.Errorf("Unexpected key for test@example.com: %v", keys[0])
+	}
+	if keys[1] != nil {
+		t.Error("Expected no key for unknown address")
+	}
+}
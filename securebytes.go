@@ -0,0 +1,103 @@
+package gpgme
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// SecureBytes holds sensitive data such as a passphrase or a decrypted
+// plaintext. Its memory is locked against swapping where the platform allows
+// it and is zeroed on Close, or when the SecureBytes is garbage collected, so
+// the secret does not linger in memory.
+//
+// SecureBytes implements io.Writer, so it can receive plaintext through
+// NewDataWriter. Growing it copies the content to a new locked buffer and
+// zeroes the old one.
+type SecureBytes struct {
+	b      []byte
+	locked bool
+}
+
+// NewSecureBytes returns an empty SecureBytes with room for size bytes
+func NewSecureBytes(size int) *SecureBytes {
+	s := &SecureBytes{}
+	s.alloc(size)
+	runtime.SetFinalizer(s, (*SecureBytes).Close)
+	return s
+}
+
+// NewSecureBytesFrom returns a SecureBytes holding a copy of b and zeroes b
+func NewSecureBytesFrom(b []byte) *SecureBytes {
+	s := NewSecureBytes(len(b))
+	s.b = append(s.b, b...)
+	wipe(b)
+	return s
+}
+
+func (s *SecureBytes) alloc(size int) {
+	s.b = make([]byte, 0, size)
+	s.locked = size > 0 && mlock(s.b[:size]) == nil
+}
+
+// Bytes returns the content. The slice is only valid until the next Write or
+// Close and must not be retained.
+func (s *SecureBytes) Bytes() []byte {
+	return s.b
+}
+
+func (s *SecureBytes) Len() int {
+	return len(s.b)
+}
+
+// Locked reports whether the memory is locked against swapping
+func (s *SecureBytes) Locked() bool {
+	return s.locked
+}
+
+func (s *SecureBytes) Write(p []byte) (int, error) {
+	if len(s.b)+len(p) > cap(s.b) {
+		old, locked := s.b, s.locked
+		s.alloc(2*cap(s.b) + len(p))
+		s.b = append(s.b, old...)
+		release(old, locked)
+	}
+	s.b = append(s.b, p...)
+	return len(p), nil
+}
+
+// Close zeroes and unlocks the memory. The SecureBytes is empty afterwards.
+func (s *SecureBytes) Close() error {
+	release(s.b, s.locked)
+	s.b, s.locked = nil, false
+	return nil
+}
+
+// PassphraseCallback returns a Callback that sends the content of s as the
+// passphrase, without converting it to a string.
+func PassphraseCallback(s *SecureBytes) Callback {
+	return func(uidHint string, prevWasBad bool, f *os.File) error {
+		if prevWasBad {
+			return fmt.Errorf("bad passphrase")
+		}
+		line := NewSecureBytes(s.Len() + 1)
+		defer line.Close()
+		_, _ = line.Write(s.Bytes())
+		_, _ = line.Write([]byte{'\n'})
+		_, err := f.Write(line.Bytes())
+		return err
+	}
+}
+
+func release(b []byte, locked bool) {
+	wipe(b[:cap(b)])
+	if locked && cap(b) > 0 {
+		_ = munlock(b[:cap(b)])
+	}
+}
+
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,29 @@
+package gpgme
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSecureBytes(t *testing.T) {
+	src := []byte("secret")
+	s := NewSecureBytesFrom(src)
+	if !bytes.Equal(src, make([]byte, len(src))) {
+		t.Errorf("Expected source to be zeroed, got %q", src)
+	}
+	for i := 0; i < 100; i++ {
+		_, err := s.Write([]byte(testData))
+		checkError(t, err)
+	}
+	want := append([]byte("secret"), bytes.Repeat([]byte(testData), 100)...)
+	diff(t, s.Bytes(), want)
+
+	b := s.Bytes()[:cap(s.Bytes())]
+	checkError(t, s.Close())
+	if s.Len() != 0 {
+		t.Errorf("Expected empty after Close, got %d bytes", s.Len())
+	}
+	if !bytes.Equal(b, make([]byte, len(b))) {
+		t.Error("Expected memory to be zeroed after Close")
+	}
+}
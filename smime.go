@@ -0,0 +1,227 @@
+package gpgme
+
+import (
+	"fmt"
+	"io"
+)
+
+// NewCMS returns a new context for the CMS protocol used by S/MIME. Keys
+// listed with it are X.509 certificates.
+func NewCMS() (*Context, error) {
+	ctx, err := New()
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.SetProtocol(ProtocolCMS); err != nil {
+		ctx.Release()
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// FindCerts returns the X.509 certificates matching pattern, as FindKeys
+// does for OpenPGP keys. With secretOnly only certificates with a private key
+// are returned.
+func FindCerts(pattern string, secretOnly bool) ([]*Key, error) {
+	ctx, err := NewCMS()
+	if err != nil {
+		return nil, err
+	}
+	defer ctx.Release()
+	return ctx.ListKeysFiltered(pattern, secretOnly)
+}
+
+// maxCertChain bounds the length of a certificate chain, guarding against
+// loops in a corrupt keybox
+const maxCertChain = 32
+
+// CertChain returns cert followed by its issuer certificates up to the root,
+// as far as they are available. The chain is incomplete if an issuer is
+// missing; the last certificate is a root if its ChainID is its own
+// fingerprint.
+func (c *Context) CertChain(cert *Key) ([]*Key, error) {
+	chain := []*Key{cert}
+	for len(chain) < maxCertChain {
+		last := chain[len(chain)-1]
+		issuer := last.ChainID()
+		if issuer == "" || issuer == last.SubKeys().Fingerprint() {
+			return chain, nil
+		}
+		keys, err := c.ListKeysFiltered(issuer, false)
+		if err != nil {
+			return chain, err
+		}
+		if len(keys) == 0 {
+			return chain, nil
+		}
+		chain = append(chain, keys[0])
+	}
+	return chain, fmt.Errorf("certificate chain longer than %d", maxCertChain)
+}
+
+// CertChainError describes where a certificate chain is broken
+type CertChainError struct {
+	// Cert is the certificate at which the chain breaks
+	Cert *Key
+	// Reason describes the problem with Cert or its issuer
+	Reason string
+}
+
+func (e *CertChainError) Error() string {
+	var subject string
+	if uid := e.Cert.UserIDs(); uid != nil {
+		subject = uid.UID()
+	}
+	return fmt.Sprintf("certificate %q: %s", subject, e.Reason)
+}
+
+// CheckCertChain returns the chain of cert as CertChain and checks that it
+// ends in a root certificate and no certificate in it is revoked, expired or
+// invalid. The first problem found from cert towards the root is returned as
+// a *CertChainError.
+func (c *Context) CheckCertChain(cert *Key) ([]*Key, error) {
+	chain, err := c.CertChain(cert)
+	if err != nil {
+		return chain, err
+	}
+	for _, k := range chain {
+		switch {
+		case k.Revoked():
+			return chain, &CertChainError{Cert: k, Reason: "revoked"}
+		case k.Expired():
+			return chain, &CertChainError{Cert: k, Reason: "expired"}
+		case k.Invalid():
+			return chain, &CertChainError{Cert: k, Reason: "invalid"}
+		}
+	}
+	root := chain[len(chain)-1]
+	if root.ChainID() != root.SubKeys().Fingerprint() {
+		reason := "issuer certificate not found"
+		if name := root.IssuerName(); name != "" {
+			reason = fmt.Sprintf("issuer certificate %q not found", name)
+		}
+		return chain, &CertChainError{Cert: root, Reason: reason}
+	}
+	return chain, nil
+}
+
+// SMIMESignature is a verified S/MIME signature with the certificate chain
+// of the signer, starting with the signer's certificate. Chain is empty if
+// the signer's certificate is not available. The reason the engine gives for
+// the validity of the chain is in Signature.ValidityReason.
+type SMIMESignature struct {
+	Signature
+	Chain []*Key
+	// ChainError is the result of checking Chain with CheckCertChain
+	ChainError error
+}
+
+// SMIMEEncrypt encrypts the data read from r for the certificates certs,
+// writing the CMS message to w.
+func SMIMEEncrypt(certs []*Key, r io.Reader, w io.Writer) error {
+	ctx, err := NewCMS()
+	if err != nil {
+		return err
+	}
+	defer ctx.Release()
+	plain, err := NewDataReader(r)
+	if err != nil {
+		return err
+	}
+	defer plain.Close()
+	cipher, err := NewDataWriter(w)
+	if err != nil {
+		return err
+	}
+	defer cipher.Close()
+	return ctx.Encrypt(certs, 0, plain, cipher)
+}
+
+// SMIMEDecrypt decrypts the CMS message read from r, writing the plaintext
+// to w.
+func SMIMEDecrypt(r io.Reader, w io.Writer) (*DecryptResult, error) {
+	ctx, err := NewCMS()
+	if err != nil {
+		return nil, err
+	}
+	defer ctx.Release()
+	cipher, err := NewDataReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer cipher.Close()
+	plain, err := NewDataWriter(w)
+	if err != nil {
+		return nil, err
+	}
+	defer plain.Close()
+	res, _, err := ctx.DecryptExt(0, cipher, plain)
+	return res, err
+}
+
+// SMIMESign signs the data read from r with the certificate signer, writing
+// the signature or signed message to w. Only SigModeNormal and SigModeDetach
+// are supported by CMS.
+func SMIMESign(signer *Key, r io.Reader, w io.Writer, mode SigMode) error {
+	ctx, err := NewCMS()
+	if err != nil {
+		return err
+	}
+	defer ctx.Release()
+	plain, err := NewDataReader(r)
+	if err != nil {
+		return err
+	}
+	defer plain.Close()
+	sig, err := NewDataWriter(w)
+	if err != nil {
+		return err
+	}
+	defer sig.Close()
+	return ctx.Sign([]*Key{signer}, plain, sig, mode)
+}
+
+// SMIMEVerify verifies the CMS signature read from sig. For a detached
+// signature signedText is the signed data and plain is nil; otherwise
+// signedText is nil and the signed data is written to plain. The signatures
+// must be checked by the caller.
+func SMIMEVerify(sig, signedText io.Reader, plain io.Writer) ([]SMIMESignature, error) {
+	ctx, err := NewCMS()
+	if err != nil {
+		return nil, err
+	}
+	defer ctx.Release()
+	sigData, err := NewDataReader(sig)
+	if err != nil {
+		return nil, err
+	}
+	defer sigData.Close()
+	var textData, plainData *Data
+	if signedText != nil {
+		if textData, err = NewDataReader(signedText); err != nil {
+			return nil, err
+		}
+		defer textData.Close()
+	}
+	if plain != nil {
+		if plainData, err = NewDataWriter(plain); err != nil {
+			return nil, err
+		}
+		defer plainData.Close()
+	}
+	_, sigs, err := ctx.Verify(sigData, textData, plainData)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.ResolveSignerKeys(sigs); err != nil {
+		return nil, err
+	}
+	res := make([]SMIMESignature, len(sigs))
+	for i := range sigs {
+		res[i].Signature = sigs[i]
+		if sigs[i].Key != nil {
+			res[i].Chain, res[i].ChainError = ctx.CheckCertChain(sigs[i].Key)
+		}
+	}
+	return res, nil
+}
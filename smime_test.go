@@ -0,0 +1,106 @@
+package gpgme
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewCMS(t *testing.T) {
+	ctx, err := NewCMS()
+	if err != nil {
+		t.Skip("CMS engine not available:", err)
+	}
+	defer ctx.Release()
+	if p := ctx.Protocol(); p != ProtocolCMS {
+		t.Errorf("Protocol() = %s, want CMS", p)
+	}
+}
+
+// testCertParams creates a self-signed certificate without passphrase
+const testCertParams = `<GnupgKeyParms format="internal">
+Key-Type: RSA
+Key-Length: 2048
+Key-Usage: sign, encrypt
+Name-DN: CN=GPGME Test,O=Example
+Name-Email: smime@example.com
+Serial: random
+%no-protection
+</GnupgKeyParms>
+`
+
+// tempCert creates a self-signed certificate in a temporary home directory,
+// which is made the default for the rest of the test.
+func tempCert(t *testing.T) *Key {
+	t.Helper()
+	ctx := tempHomeContext(t)
+	homeDir := ctx.EngineInfo().ForProtocol(ProtocolOpenPGP).HomeDir()
+	// The self-signed certificate is not a trusted root and has no CRL.
+	conf := "disable-crl-checks\ndisable-policy-checks\nalways-trust\n"
+	checkError(t, ioutil.WriteFile(filepath.Join(homeDir, "gpgsm.conf"), []byte(conf), 0o600))
+	useHomeDir(t, ctx)
+
+	cms, err := NewCMS()
+	if err != nil {
+		t.Skip("CMS engine not available:", err)
+	}
+	defer cms.Release()
+	var buf bytes.Buffer
+	pub, err := NewDataWriter(&buf)
+	checkError(t, err)
+	defer pub.Close()
+	if _, err := cms.GenKey(testCertParams, pub, nil); err != nil {
+		t.Skip("gpgsm cannot create a self-signed certificate:", err)
+	}
+	certData, err := NewDataBytes(buf.Bytes())
+	checkError(t, err)
+	defer certData.Close()
+	res, err := cms.Import(certData)
+	checkError(t, err)
+	if len(res.Imports) != 1 {
+		t.Fatalf("Expected 1 imported certificate, got %+v", res.Imports)
+	}
+	certs, err := FindCerts(res.Imports[0].Fingerprint, true)
+	checkError(t, err)
+	if len(certs) != 1 {
+		t.Fatalf("Expected 1 certificate, got %d", len(certs))
+	}
+	return certs[0]
+}
+
+func TestSMIME_encryptDecrypt(t *testing.T) {
+	cert := tempCert(t)
+	if cert.Protocol() != ProtocolCMS {
+		t.Errorf("Expected CMS certificate, got %s", cert.Protocol())
+	}
+
+	var cipher bytes.Buffer
+	checkError(t, SMIMEEncrypt([]*Key{cert}, strings.NewReader(testData), &cipher))
+	var plain bytes.Buffer
+	_, err := SMIMEDecrypt(&cipher, &plain)
+	checkError(t, err)
+	diff(t, plain.Bytes(), []byte(testData))
+}
+
+func TestSMIME_signVerify(t *testing.T) {
+	cert := tempCert(t)
+	fpr := cert.SubKeys().Fingerprint()
+
+	var sig bytes.Buffer
+	checkError(t, SMIMESign(cert, strings.NewReader(testData), &sig, SigModeDetach))
+	sigs, err := SMIMEVerify(&sig, strings.NewReader(testData), nil)
+	checkError(t, err)
+	if len(sigs) != 1 {
+		t.Fatalf("Expected 1 signature, got %d", len(sigs))
+	}
+	if sigs[0].Fingerprint != fpr {
+		t.Errorf("Fingerprint = %s, want %s", sigs[0].Fingerprint, fpr)
+	}
+	// A self-signed certificate is its own chain
+	if len(sigs[0].Chain) != 1 || sigs[0].Chain[0].SubKeys().Fingerprint() != fpr {
+		t.Errorf("Unexpected chain %v", sigs[0].Chain)
+	}
+	checkError(t, sigs[0].ChainError)
+}
@@ -0,0 +1,114 @@
+package gpgme
+
+import "io"
+
+// EncryptingWriter encrypts the data written to it, see NewEncryptingWriter
+type EncryptingWriter struct {
+	pw   *io.PipeWriter
+	done chan struct{}
+	err  error
+}
+
+// NewEncryptingWriter returns a writer that encrypts the data written to it
+// for recipients and writes the ciphertext to dst as it is produced, so a
+// producer such as archive/tar can be encrypted without temporary files.
+//
+// The encryption runs on ctx in a separate goroutine; ctx must not be used
+// until Close has returned. Close must be called to flush the ciphertext
+// and returns the result of the encryption.
+func NewEncryptingWriter(ctx *Context, recipients []*Key, dst io.Writer) (*EncryptingWriter, error) {
+	pr, pw := io.Pipe()
+	plain, err := NewDataReader(pr)
+	if err != nil {
+		return nil, err
+	}
+	cipher, err := NewDataWriter(dst)
+	if err != nil {
+		plain.Close()
+		return nil, err
+	}
+	w := &EncryptingWriter{pw: pw, done: make(chan struct{})}
+	go func() {
+		defer close(w.done)
+		defer plain.Close()
+		defer cipher.Close()
+		w.err = ctx.Encrypt(recipients, 0, plain, cipher)
+		// Unblock writers if the operation ended before all input was read
+		pr.CloseWithError(w.err)
+	}()
+	return w, nil
+}
+
+func (w *EncryptingWriter) Write(p []byte) (int, error) {
+	return w.pw.Write(p)
+}
+
+// Close ends the plaintext, waits for the encryption to finish and returns
+// its error.
+func (w *EncryptingWriter) Close() error {
+	w.pw.Close()
+	<-w.done
+	return w.err
+}
+
+// DecryptingReader decrypts the data read from a source, see
+// NewDecryptingReader
+type DecryptingReader struct {
+	pr     *io.PipeReader
+	done   chan struct{}
+	result *DecryptResult
+	sigs   []Signature
+	err    error
+}
+
+// NewDecryptingReader returns a reader of the plaintext of the message read
+// from src. The message is decrypted and its signatures verified as it is
+// read, so large files can be consumed without holding the plaintext in
+// memory.
+//
+// The decryption runs on ctx in a separate goroutine; ctx must not be used
+// until Close has returned. Closing the reader before EOF aborts the
+// operation. The plaintext limit of ctx, see SetMaxPlaintextBytes, applies.
+func NewDecryptingReader(ctx *Context, src io.Reader) (*DecryptingReader, error) {
+	cipher, err := NewDataReader(src)
+	if err != nil {
+		return nil, err
+	}
+	pr, pw := io.Pipe()
+	plain, err := NewDataWriter(pw)
+	if err != nil {
+		cipher.Close()
+		return nil, err
+	}
+	r := &DecryptingReader{pr: pr, done: make(chan struct{})}
+	go func() {
+		defer close(r.done)
+		defer cipher.Close()
+		defer plain.Close()
+		r.result, r.sigs, r.err = ctx.DecryptExt(DecryptVerify, cipher, plain)
+		pw.CloseWithError(r.err)
+	}()
+	return r, nil
+}
+
+func (r *DecryptingReader) Read(p []byte) (int, error) {
+	return r.pr.Read(p)
+}
+
+// Close waits for the decryption to finish and returns its error
+func (r *DecryptingReader) Close() error {
+	r.pr.Close()
+	<-r.done
+	return r.err
+}
+
+// Result returns the decrypt result. It is only available after Close.
+func (r *DecryptingReader) Result() *DecryptResult {
+	return r.result
+}
+
+// Signatures returns the verified signatures of the plaintext. They are only
+// available after Close.
+func (r *DecryptingReader) Signatures() []Signature {
+	return r.sigs
+}
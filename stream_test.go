@@ -0,0 +1,44 @@
+package gpgme
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestNewEncryptingWriter(t *testing.T) {
+	ctx, err := New()
+	checkError(t, err)
+	ctx.SetArmor(true)
+
+	keys, err := FindKeys("test@example.com", true)
+	checkError(t, err)
+
+	var buf bytes.Buffer
+	w, err := NewEncryptingWriter(ctx, keys, &buf)
+	checkError(t, err)
+	for i := 0; i < 100; i++ {
+		_, err := io.WriteString(w, testData)
+		checkError(t, err)
+	}
+	checkError(t, w.Close())
+	if !bytes.HasPrefix(buf.Bytes(), []byte("-----BEGIN PGP MESSAGE-----")) {
+		t.Errorf("Expected armored message, got %q", buf.Bytes())
+	}
+}
+
+func TestNewDecryptingReader(t *testing.T) {
+	ctx := ctxWithCallback(t)
+
+	r, err := NewDecryptingReader(ctx, strings.NewReader(testCipherText))
+	checkError(t, err)
+	out, err := ioutil.ReadAll(r)
+	checkError(t, err)
+	checkError(t, r.Close())
+	diff(t, out, []byte("Test message\n"))
+	if res := r.Result(); res == nil || len(res.Recipients) != 1 {
+		t.Errorf("Unexpected decrypt result %+v", res)
+	}
+}
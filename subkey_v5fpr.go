@@ -0,0 +1,16 @@
+//go:build gpgme_1_23
+// +build gpgme_1_23
+
+package gpgme
+
+// #include <gpgme.h>
+import "C"
+
+// KeyListModeWithV5FPR adds the v5 fingerprints to key listings
+const KeyListModeWithV5FPR KeyListMode = C.GPGME_KEYLIST_MODE_WITH_V5FPR
+
+// V5Fingerprint returns the v5 fingerprint of the subkey. It is only
+// available when listing with KeyListModeWithV5FPR.
+func (k *SubKey) V5Fingerprint() string {
+	return C.GoString(k.k.v5fpr)
+}
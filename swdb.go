@@ -0,0 +1,94 @@
+package gpgme
+
+// #include <stdlib.h>
+// #include <gpgme.h>
+// #include "go_gpgme.h"
+import "C"
+
+import (
+	"errors"
+	"runtime"
+	"time"
+	"unsafe"
+)
+
+// SWDBResult is the information about a software package from the software
+// version database, as shown by gpgconf --query-swdb
+type SWDBResult struct {
+	Name             string
+	InstalledVersion string
+	// Version is the latest released version
+	Version     string
+	ReleaseDate time.Time
+	// Created is when the database was created, Retrieved when it was last
+	// fetched
+	Created   time.Time
+	Retrieved time.Time
+	// Warning is set if the database is not valid or outdated
+	Warning bool
+	// Update is set if a newer version is available, Urgent if it is a
+	// security update
+	Update bool
+	Urgent bool
+	// NoInfo is set if the database does not know the package, Unknown if
+	// the installed version could not be determined
+	NoInfo  bool
+	Unknown bool
+	// TooOld is set if the database is too old to be used
+	TooOld bool
+	Error  bool
+}
+
+// QuerySWDB looks up name, "gnupg" if empty, in the software version database
+// maintained by gpgconf. installedVersion is the version to compare with; if
+// empty the version of the installed GnuPG is used for "gnupg". The query is
+// run with the gpgconf engine, using the home directory of c.
+func (c *Context) QuerySWDB(name, installedVersion string) (*SWDBResult, error) {
+	conf, err := c.newGPGConfContext()
+	if err != nil {
+		return nil, err
+	}
+	defer conf.Release()
+	return conf.querySWDB(name, installedVersion)
+}
+
+func (c *Context) querySWDB(name, installedVersion string) (*SWDBResult, error) {
+	var cname, civersion *C.char
+	if name != "" {
+		cname = C.CString(name)
+		defer C.free(unsafe.Pointer(cname))
+	}
+	if installedVersion != "" {
+		civersion = C.CString(installedVersion)
+		defer C.free(unsafe.Pointer(civersion))
+	}
+	err := handleError(C.gpgme_op_query_swdb(c.ctx, cname, civersion, 0))
+	runtime.KeepAlive(c)
+	if err != nil {
+		return nil, err
+	}
+
+	res := C.gpgme_op_query_swdb_result(c.ctx)
+	runtime.KeepAlive(c)
+	// NOTE: c must be live as long as we are accessing res.
+	if res == nil {
+		return nil, errors.New("no software version database result")
+	}
+	r := &SWDBResult{
+		Name:             C.GoString(res.name),
+		InstalledVersion: C.GoString(res.iversion),
+		Version:          C.GoString(res.version),
+		ReleaseDate:      unixTime(int64(res.reldate)),
+		Created:          unixTime(int64(res.created)),
+		Retrieved:        unixTime(int64(res.retrieved)),
+		Warning:          C.swdb_result_warning(res) != 0,
+		Update:           C.swdb_result_update(res) != 0,
+		Urgent:           C.swdb_result_urgent(res) != 0,
+		NoInfo:           C.swdb_result_noinfo(res) != 0,
+		Unknown:          C.swdb_result_unknown(res) != 0,
+		TooOld:           C.swdb_result_tooold(res) != 0,
+		Error:            C.swdb_result_error(res) != 0,
+	}
+	runtime.KeepAlive(c) // for all accesses to res above
+	return r, nil
+}
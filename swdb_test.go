@@ -0,0 +1,19 @@
+package gpgme
+
+import "testing"
+
+func TestContext_QuerySWDB(t *testing.T) {
+	ensureVersion(t, "2.", "the software version database requires GPG v2.x")
+
+	ctx, err := New()
+	checkError(t, err)
+	defer ctx.Release()
+	if GetDirInfo("gpgconf-name") == "" {
+		t.Skip("gpgconf not available")
+	}
+	res, err := ctx.QuerySWDB("gnupg", "2.0.0")
+	checkError(t, err)
+	if res.Name != "gnupg" || res.InstalledVersion != "2.0.0" {
+		t.Errorf("Unexpected result %+v", res)
+	}
+}
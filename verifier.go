@@ -0,0 +1,92 @@
+package gpgme
+
+import (
+	"io"
+	"sync"
+)
+
+// VerifyItem is a detached signature and its signed data to be verified by
+// a Verifier
+type VerifyItem struct {
+	Signature io.Reader
+	Data      io.Reader
+}
+
+// VerifyItemResult is the outcome of verifying one VerifyItem
+type VerifyItemResult struct {
+	Signatures []Signature
+	Err        error
+}
+
+// Verifier verifies detached signatures concurrently with a fixed pool of
+// contexts. A Verifier is safe for concurrent use.
+type Verifier struct {
+	pool chan *Context
+}
+
+// NewVerifier returns a Verifier with n contexts, so that at most n
+// signatures are verified at the same time.
+func NewVerifier(n int) (*Verifier, error) {
+	if n < 1 {
+		n = 1
+	}
+	v := &Verifier{pool: make(chan *Context, n)}
+	for i := 0; i < n; i++ {
+		ctx, err := New()
+		if err != nil {
+			v.Close()
+			return nil, err
+		}
+		v.pool <- ctx
+	}
+	return v, nil
+}
+
+// Verify verifies a single detached signature with one of the pool's
+// contexts, waiting for a context to become free.
+func (v *Verifier) Verify(sig, data io.Reader) ([]Signature, error) {
+	ctx := <-v.pool
+	defer func() { v.pool <- ctx }()
+	sigData, err := NewDataReader(sig)
+	if err != nil {
+		return nil, err
+	}
+	defer sigData.Close()
+	signedText, err := NewDataReader(data)
+	if err != nil {
+		return nil, err
+	}
+	defer signedText.Close()
+	_, sigs, err := ctx.Verify(sigData, signedText, nil)
+	return sigs, err
+}
+
+// VerifyAll verifies all of items concurrently. The results are in the order
+// of items; a failure of one item does not affect the others.
+func (v *Verifier) VerifyAll(items []VerifyItem) []VerifyItemResult {
+	results := make([]VerifyItemResult, len(items))
+	var wg sync.WaitGroup
+	for i := range items {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sigs, err := v.Verify(items[i].Signature, items[i].Data)
+			results[i] = VerifyItemResult{Signatures: sigs, Err: err}
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// Close releases the pool's contexts. It must not be called while
+// verifications are in progress.
+func (v *Verifier) Close() {
+	for {
+		select {
+		case ctx := <-v.pool:
+			ctx.Release()
+		default:
+			return
+		}
+	}
+}
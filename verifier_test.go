@@ -0,0 +1,40 @@
+package gpgme
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestVerifier(t *testing.T) {
+	ctx := tempHomeContext(t)
+	key := newTestKey(t, ctx, "Verifier <verifier@example.com>", "ed25519", CreateSign|CreateNoPassword)
+	r, err := SignDetached(key, strings.NewReader(testData))
+	checkError(t, err)
+	sig, err := ioutil.ReadAll(r)
+	checkError(t, err)
+
+	useHomeDir(t, ctx)
+	v, err := NewVerifier(2)
+	checkError(t, err)
+	defer v.Close()
+
+	items := make([]VerifyItem, 5)
+	for i := range items {
+		data := testData
+		if i == 3 {
+			data = "tampered"
+		}
+		items[i] = VerifyItem{Signature: bytes.NewReader(sig), Data: strings.NewReader(data)}
+	}
+	for i, res := range v.VerifyAll(items) {
+		checkError(t, res.Err)
+		if len(res.Signatures) != 1 {
+			t.Fatalf("item %d: expected 1 signature, got %d", i, len(res.Signatures))
+		}
+		if bad := res.Signatures[0].Status != nil; bad != (i == 3) {
+			t.Errorf("item %d: unexpected status %v", i, res.Signatures[0].Status)
+		}
+	}
+}